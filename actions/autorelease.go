@@ -1,8 +1,10 @@
 package actions
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"time"
 
 	"github.com/open-ch/kaeter/git"
@@ -19,16 +21,23 @@ type AutoReleaseConfig struct {
 	Tags           *[]string // nil = don't change, empty slice = clear tags, non-empty = set tags
 	RepositoryRef  string
 	RepositoryRoot string
-	SkipLint       bool
-	versionsPath   string
-	versions       *modules.Versions
+	// ScaffoldChangelog adds an entry for the version to the changelog which, like versions.yaml, is left unstaged
+	ScaffoldChangelog bool
+	SkipLint          bool
+	versionsPath      string
+	versions          *modules.Versions
+	changelogPath     string // Set once an entry was added to the changelog
 }
 
+// autoreleaseVersionHook is the hook providing the version to release when none is given
+const autoreleaseVersionHook = "autorelease-version"
+
 // AutoReleaseHash holds the constant for the key we use instead of hashes for autorelease.
 const AutoReleaseHash = "AUTORELEASE"
 
-// AutoRelease updates the versions.yaml to request an autorelease from CI once merged
-func AutoRelease(config *AutoReleaseConfig) error {
+// AutoRelease updates the versions.yaml to request an autorelease from CI once merged,
+// the pre-prepare hook of the module runs before versions.yaml is modified.
+func AutoRelease(ctx context.Context, config *AutoReleaseConfig) error {
 	refTime := time.Now().UTC()
 
 	err := config.loadVersions()
@@ -37,7 +46,7 @@ func AutoRelease(config *AutoReleaseConfig) error {
 	}
 
 	if config.getLastReleaseEntry().CommitID == "AUTORELEASE" {
-		err = config.bumpLastReleaseTimestamp(&refTime)
+		err = config.bumpLastReleaseTimestamp(ctx, &refTime)
 		if err != nil {
 			return err
 		}
@@ -47,7 +56,7 @@ func AutoRelease(config *AutoReleaseConfig) error {
 	if config.ReleaseVersion == "" {
 		log.Debug("Version not defined, attempting version hook")
 		var hookVersion string
-		hookVersion, verr := config.getReleaseVersionFromHooks()
+		hookVersion, verr := config.getReleaseVersionFromHooks(ctx)
 		if verr != nil {
 			return verr
 		}
@@ -59,7 +68,7 @@ func AutoRelease(config *AutoReleaseConfig) error {
 		"version", config.ReleaseVersion,
 		"modulePath", config.ModulePath,
 		"repositoryRef", config.RepositoryRef)
-	versions, err := config.addAutoReleaseVersionEntry(&refTime)
+	versions, err := config.addAutoReleaseVersionEntry(ctx, &refTime)
 	if err != nil {
 		return err
 	}
@@ -69,8 +78,8 @@ func AutoRelease(config *AutoReleaseConfig) error {
 	return config.validateAutoreleaseAndRevertOnError()
 }
 
-func (config *AutoReleaseConfig) getReleaseVersionFromHooks() (string, error) {
-	if hooks.HasHook("autorelease-version", config.versions) {
+func (config *AutoReleaseConfig) getReleaseVersionFromHooks(ctx context.Context) (string, error) {
+	if hooks.HasHook(autoreleaseVersionHook, config.versions) {
 		currentVersion := ""
 		currentHash := ""
 		releasedVersions := len(config.versions.ReleasedVersions)
@@ -78,8 +87,15 @@ func (config *AutoReleaseConfig) getReleaseVersionFromHooks() (string, error) {
 			currentVersion = config.versions.ReleasedVersions[releasedVersions-1].Number.String()
 			currentHash = config.versions.ReleasedVersions[releasedVersions-1].CommitID
 		}
+		protocol, err := hooks.Protocol(autoreleaseVersionHook, config.versions)
+		if err != nil {
+			return "", err
+		}
+		if protocol == hooks.ProtocolJSON {
+			return config.getReleaseVersionFromJSONHook(ctx, currentHash)
+		}
 		return hooks.RunHook(
-			"autorelease-version", config.versions,
+			ctx, autoreleaseVersionHook, config.versions,
 			config.RepositoryRoot,
 			[]string{
 				config.ModulePath,
@@ -92,6 +108,33 @@ func (config *AutoReleaseConfig) getReleaseVersionFromHooks() (string, error) {
 	return "", errors.New(`flag "version" not set: specifying a version to release is required`)
 }
 
+// getReleaseVersionFromJSONHook passes the module and the changes since the current version to
+// the hook, tags it responds with are used unless tags were set explicitly.
+func (config *AutoReleaseConfig) getReleaseVersionFromJSONHook(ctx context.Context, currentHash string) (string, error) {
+	relativePath, err := relativeModulePath(config.RepositoryRoot, filepath.Dir(config.versionsPath))
+	if err != nil {
+		return "", err
+	}
+	request := hooks.NewRequest(config.versions, relativePath)
+	request.Changeset, err = moduleChangeset(config.RepositoryRoot, relativePath, currentHash, "HEAD")
+	if err != nil {
+		return "", err
+	}
+
+	response, err := hooks.RunJSONHook(ctx, autoreleaseVersionHook, config.versions, config.RepositoryRoot, request, nil)
+	logHookMessages(autoreleaseVersionHook, config.versions.ID, response)
+	if err != nil {
+		return "", err
+	}
+	if response.Version == "" {
+		return "", fmt.Errorf("%s hook did not respond with a version", autoreleaseVersionHook)
+	}
+	if config.Tags == nil && len(response.Tags) > 0 {
+		config.Tags = &response.Tags
+	}
+	return response.Version, nil
+}
+
 func (config *AutoReleaseConfig) lintKaeterModule() error {
 	// TODO instead of computing and reading versions file multiple times load once and pass around directly.
 	err := lint.CheckModuleFromVersionsFile(lint.CheckConfig{RepoRoot: config.RepositoryRoot}, config.versionsPath)
@@ -102,7 +145,7 @@ func (config *AutoReleaseConfig) lintKaeterModule() error {
 	return nil
 }
 
-func (config *AutoReleaseConfig) addAutoReleaseVersionEntry(refTime *time.Time) (*modules.Versions, error) {
+func (config *AutoReleaseConfig) addAutoReleaseVersionEntry(ctx context.Context, refTime *time.Time) (*modules.Versions, error) {
 	log.Debug("Module identifier", "moduleID", config.versions.ID)
 	newReleaseMeta, err := config.versions.AddRelease(refTime, modules.BumpPatch, config.ReleaseVersion, AutoReleaseHash)
 	if err != nil {
@@ -111,17 +154,34 @@ func (config *AutoReleaseConfig) addAutoReleaseVersionEntry(refTime *time.Time)
 
 	applyTags(newReleaseMeta, config.Tags)
 
+	err = runPrePrepareHook(ctx, config.versions, AutoReleaseHash, config.RepositoryRoot, filepath.Dir(config.versionsPath))
+	if err != nil {
+		return nil, err
+	}
+
 	log.Debug("Updated versions.yaml", "versionsPath", config.versionsPath, "autoreleaseVersion", newReleaseMeta.Number.String())
 	err = config.versions.SaveToFile(config.versionsPath)
+	if err != nil {
+		return nil, err
+	}
 
-	return config.versions, err
+	return config.versions, config.scaffoldChangelog(refTime)
+}
+
+func (config *AutoReleaseConfig) scaffoldChangelog(refTime *time.Time) error {
+	if !config.ScaffoldChangelog {
+		return nil
+	}
+	changelogPath, err := addChangelogEntry(config.RepositoryRoot, filepath.Dir(config.versionsPath), config.versions, "HEAD", refTime)
+	config.changelogPath = changelogPath
+	return err
 }
 
 func (config *AutoReleaseConfig) getLastReleaseEntry() *modules.VersionMetadata {
 	return config.versions.ReleasedVersions[len(config.versions.ReleasedVersions)-1]
 }
 
-func (config *AutoReleaseConfig) bumpLastReleaseTimestamp(refTime *time.Time) error {
+func (config *AutoReleaseConfig) bumpLastReleaseTimestamp(ctx context.Context, refTime *time.Time) error {
 	latestVersion := config.getLastReleaseEntry()
 	if config.ReleaseVersion != "" && config.ReleaseVersion != latestVersion.Number.String() {
 		return fmt.Errorf("cannot autorelease %s an autorelease is still pending for %s", config.ReleaseVersion, latestVersion.Number)
@@ -132,7 +192,17 @@ func (config *AutoReleaseConfig) bumpLastReleaseTimestamp(refTime *time.Time) er
 
 	applyTags(latestVersion, config.Tags)
 
-	return config.versions.SaveToFile(config.versionsPath)
+	err := runPrePrepareHook(ctx, config.versions, AutoReleaseHash, config.RepositoryRoot, filepath.Dir(config.versionsPath))
+	if err != nil {
+		return err
+	}
+
+	err = config.versions.SaveToFile(config.versionsPath)
+	if err != nil {
+		return err
+	}
+
+	return config.scaffoldChangelog(refTime)
 }
 
 func (config *AutoReleaseConfig) validateAutoreleaseAndRevertOnError() error {
@@ -164,6 +234,14 @@ func (config *AutoReleaseConfig) restoreVersions() error {
 		log.Debug("git restore failure", "output", output)
 		return fmt.Errorf("failed to reset versions.yaml using git: %w", err)
 	}
+	if config.changelogPath == "" {
+		return nil
+	}
+	output, err = git.RestoreFile(config.RepositoryRoot, config.changelogPath)
+	if err != nil {
+		log.Debug("git restore failure", "output", output)
+		return fmt.Errorf("failed to reset changelog using git: %w", err)
+	}
 	return nil
 }
 
@@ -17,6 +17,7 @@ func TestAutoRelease(t *testing.T) {
 		expectedYAMLVersion       string
 		expectError               bool
 		name                      string
+		scaffoldChangelog         bool
 		skipLint                  bool
 		skipReadme                bool
 		version                   string
@@ -75,6 +76,20 @@ func TestAutoRelease(t *testing.T) {
 			version:                   "1.0.0",
 			customStartingVersionYAML: mocks.PendingAutoreleaseVersionsYAML,
 		},
+		{
+			name:              "Scaffolds missing changelog entry",
+			changelogContent:  "# CHANGELOG\n",
+			version:           "1.0.0",
+			scaffoldChangelog: true,
+		},
+		{
+			name:                      "Reverts scaffolded changelog entry when lint fails",
+			changelogContent:          "# CHANGELOG\n",
+			version:                   "1.0.0",
+			scaffoldChangelog:         true,
+			customStartingVersionYAML: mocks.EmptyVersionsGoWorkDepYAML, // go.work is missing
+			expectError:               true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -90,20 +105,31 @@ func TestAutoRelease(t *testing.T) {
 				CHANGELOG:         tc.changelogContent,
 			})
 			config := &AutoReleaseConfig{
-				ModulePath:     testFolder,
-				ReleaseVersion: tc.version,
-				RepositoryRef:  "main",
-				RepositoryRoot: testFolder,
-				SkipLint:       tc.skipLint,
+				ModulePath:        testFolder,
+				ReleaseVersion:    tc.version,
+				RepositoryRef:     "main",
+				RepositoryRoot:    testFolder,
+				ScaffoldChangelog: tc.scaffoldChangelog,
+				SkipLint:          tc.skipLint,
 			}
 
-			err := AutoRelease(config)
+			err := AutoRelease(t.Context(), config)
+
+			if tc.scaffoldChangelog {
+				changelog, readErr := os.ReadFile(filepath.Join(testFolder, "CHANGELOG.md"))
+				assert.NoError(t, readErr)
+				if tc.expectError {
+					assert.Equal(t, tc.changelogContent, string(changelog))
+				} else {
+					assert.Contains(t, string(changelog), "## "+tc.version+" - ")
+				}
+			}
 
 			if tc.expectError {
 				assert.Error(t, err)
 				verstionsYaml, err := os.ReadFile(filepath.Join(testFolder, "versions.yaml"))
 				assert.NoError(t, err)
-				assert.Equal(t, string(verstionsYaml), mocks.EmptyVersionsYAML)
+				assert.Equal(t, string(verstionsYaml), versionsYaml)
 			} else {
 				assert.NoError(t, err)
 				verstionsYaml, err := os.ReadFile(filepath.Join(testFolder, "versions.yaml"))
@@ -178,7 +204,7 @@ func TestGetReleaseVersionFromHooks(t *testing.T) {
 				versions:       tc.versions,
 			}
 
-			version, err := config.getReleaseVersionFromHooks()
+			version, err := config.getReleaseVersionFromHooks(t.Context())
 
 			if tc.expectError {
 				assert.ErrorContains(t, err, tc.exportErrorContains)
@@ -189,3 +215,33 @@ func TestGetReleaseVersionFromHooks(t *testing.T) {
 		})
 	}
 }
+
+func TestAutoReleaseJSONVersionHook(t *testing.T) {
+	versionsYAML := mocks.EmptyVersionsYAML + `
+metadata:
+  annotations:
+    open.ch/kaeter-hook/autorelease-version: hooks/version.sh
+    open.ch/kaeter-hook-protocol/autorelease-version: json
+`
+	testFolder, _ := mocks.CreateKaeterRepo(t, &mocks.KaeterModuleConfig{
+		Makefile:     mocks.EmptyMakefileContent,
+		VersionsYAML: versionsYAML,
+	})
+	createRecordingHook(t, testFolder, "version.sh",
+		"#!/bin/sh\ncat > /dev/null\necho '{\"version\": \"1.1.0\", \"tags\": [\"beta\"], \"verdict\": \"pass\"}'\n")
+	config := &AutoReleaseConfig{
+		ModulePath:     testFolder,
+		RepositoryRef:  "main",
+		RepositoryRoot: testFolder,
+		SkipLint:       true,
+	}
+
+	err := AutoRelease(t.Context(), config)
+
+	assert.NoError(t, err)
+	versions, err := modules.ReadFromFile(filepath.Join(testFolder, "versions.yaml"))
+	assert.NoError(t, err)
+	latestVersion := versions.ReleasedVersions[len(versions.ReleasedVersions)-1]
+	assert.Equal(t, "1.1.0", latestVersion.Number.String())
+	assert.Equal(t, []string{"beta"}, latestVersion.Tags)
+}
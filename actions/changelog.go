@@ -0,0 +1,53 @@
+package actions
+
+import (
+	"strings"
+	"time"
+
+	"github.com/open-ch/kaeter/git"
+	"github.com/open-ch/kaeter/lint"
+	"github.com/open-ch/kaeter/log"
+	"github.com/open-ch/kaeter/modules"
+)
+
+// addChangelogEntry adds an entry for the latest version of the module to its changelog, pre-filled with
+// the subjects of the commits changing the module since the previous release up to until.
+// Returns the path of the changelog, empty if it already had an entry for the version.
+func addChangelogEntry(repoRoot, absModulePath string, versions *modules.Versions, until string, date *time.Time) (string, error) {
+	releasedVersions := versions.ReleasedVersions
+	latestVersion := releasedVersions[len(releasedVersions)-1]
+	entry := &lint.NewChangelogEntry{
+		Version: latestVersion.Number.String(),
+		Date:    *date,
+		Author:  git.Author(repoRoot),
+	}
+
+	if len(releasedVersions) > 1 {
+		relativePath, err := relativeModulePath(repoRoot, absModulePath)
+		if err != nil {
+			return "", err
+		}
+		previousCommit := releasedVersions[len(releasedVersions)-2].CommitID
+		changeset, err := moduleChangeset(repoRoot, relativePath, previousCommit, until)
+		if err != nil {
+			return "", err
+		}
+		if changeset != nil {
+			for _, commit := range changeset.Commits {
+				_, subject, _ := strings.Cut(commit, " ")
+				entry.Changes = append(entry.Changes, subject)
+			}
+		}
+	}
+
+	changelogPath, added, err := lint.AddChangelogEntry(absModulePath, entry)
+	if err != nil {
+		return "", err
+	}
+	if !added {
+		log.Info("Changelog already has an entry for the version", "moduleID", versions.ID, "version", entry.Version)
+		return "", nil
+	}
+	log.Info("Changelog entry added, review it before merging", "moduleID", versions.ID, "changelog", changelogPath)
+	return changelogPath, nil
+}
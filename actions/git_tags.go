@@ -0,0 +1,192 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/viper"
+
+	"github.com/open-ch/kaeter/git"
+	"github.com/open-ch/kaeter/inventory"
+	"github.com/open-ch/kaeter/log"
+	"github.com/open-ch/kaeter/modules"
+)
+
+// GitTagAnnotation opts a module in to annotated git tags created for each release, the value is the
+// go template of the tag name, i.e. open.ch/kaeter-git-tag: "{{.Path}}/v{{.Version}}" (see GitTagData).
+const GitTagAnnotation = "open.ch/kaeter-git-tag"
+
+// gitTagViperKey is the key of the repository wide git tag template in the kaeter config
+const gitTagViperKey = "git.tag.template"
+
+// GitTagData is available to git tag templates.
+type GitTagData struct {
+	ModuleID string // with : replaced by / as git refs cannot contain colons
+	Path     string // relative to the repository root, with forward slashes, empty for a module at the root
+	Version  string
+	Commit   string
+}
+
+// gitTagName renders the tag of the release from the template of the module, or else the repository
+// default from the kaeter config. The name is empty if the module does not use git tags.
+func gitTagName(repoRoot string, versions *modules.Versions, data *GitTagData) (string, error) {
+	tagTemplate := gitTagTemplate(versions)
+	if tagTemplate == "" {
+		return "", nil
+	}
+
+	parsedTemplate, err := template.New("git-tag").Option("missingkey=error").Parse(tagTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid git tag template for %s: %w", versions.ID, err)
+	}
+	templateData := *data
+	templateData.ModuleID = strings.ReplaceAll(data.ModuleID, ":", "/")
+	var tagName strings.Builder
+	if err := parsedTemplate.Execute(&tagName, &templateData); err != nil {
+		return "", fmt.Errorf("unable to render git tag for %s: %w", versions.ID, err)
+	}
+	// Templates such as {{.Path}}/v{{.Version}} start with a / for a module at the root
+	name := strings.TrimPrefix(tagName.String(), "/")
+	if err := git.CheckRefFormat(repoRoot, "refs/tags/"+name); err != nil {
+		return "", fmt.Errorf("invalid git tag for %s: %w", versions.ID, err)
+	}
+	return name, nil
+}
+
+// gitTagPath returns the module path passed to the tag templates, empty for a module at the repository root
+func gitTagPath(relativeModulePath string) string {
+	if relativeModulePath == "." {
+		return ""
+	}
+	return filepath.ToSlash(relativeModulePath)
+}
+
+// gitTagTemplate returns the git tag template of the module, empty if it does not use git tags.
+func gitTagTemplate(versions *modules.Versions) string {
+	if versions.Metadata != nil {
+		if moduleTemplate, found := versions.Metadata.Annotations[GitTagAnnotation]; found {
+			return moduleTemplate
+		}
+	}
+	return viper.GetString(gitTagViperKey)
+}
+
+// ensureGitTag creates the annotated tag pointing to the commit unless it already exists, or only reports it
+// missing unless create is set. An existing tag pointing to another commit is an error, tags are never moved.
+func ensureGitTag(repoRoot, tagName, commit, message string, create bool) (created bool, err error) {
+	commitHash, err := git.ResolveRevision(repoRoot, commit+"^{commit}")
+	if err != nil {
+		return false, fmt.Errorf("unable to resolve commit %s of tag %s: %w", commit, tagName, err)
+	}
+	taggedHash, err := git.ResolveRevision(repoRoot, "refs/tags/"+tagName+"^{commit}")
+	if err == nil {
+		if taggedHash != commitHash {
+			return false, fmt.Errorf("tag %s points to %s instead of %s", tagName, taggedHash, commitHash)
+		}
+		return false, nil
+	}
+	if !create {
+		return false, fmt.Errorf("tag %s is missing", tagName)
+	}
+
+	output, err := git.TagAnnotated(repoRoot, tagName, message, commitHash)
+	if err != nil {
+		return false, fmt.Errorf("unable to create tag %s: %s\n%w", tagName, output, err)
+	}
+	return true, nil
+}
+
+// releaseTag is the git tag of a release, rendered before the release steps run and created once they succeeded.
+type releaseTag struct {
+	repoRoot string
+	name     string
+	commit   string
+}
+
+// newReleaseTag renders and validates the git tag of the latest version of the module,
+// nil if the module does not use git tags.
+func newReleaseTag(repoRoot, modulePath string, versions *modules.Versions, releaseCommit string) (*releaseTag, error) {
+	modulePath, err := relativeModulePath(repoRoot, modulePath)
+	if err != nil {
+		return nil, err
+	}
+	latestVersion := versions.ReleasedVersions[len(versions.ReleasedVersions)-1]
+	tagName, err := gitTagName(repoRoot, versions, &GitTagData{
+		ModuleID: versions.ID,
+		Path:     gitTagPath(modulePath),
+		Version:  latestVersion.Number.String(),
+		Commit:   releaseCommit,
+	})
+	if err != nil || tagName == "" {
+		return nil, err
+	}
+	return &releaseTag{repoRoot: repoRoot, name: tagName, commit: releaseCommit}, nil
+}
+
+// create tags the release commit unless the tag already exists.
+func (tag *releaseTag) create(versions *modules.Versions) error {
+	latestVersion := versions.ReleasedVersions[len(versions.ReleasedVersions)-1]
+	message := gitTagMessage(versions.ID, latestVersion.Number.String())
+	created, err := ensureGitTag(tag.repoRoot, tag.name, tag.commit, message, true)
+	if err != nil {
+		return err
+	}
+	log.Info("Release tagged", "moduleID", versions.ID, "tag", tag.name, "created", created)
+	return nil
+}
+
+func gitTagMessage(moduleID, version string) string {
+	return fmt.Sprintf("Release %s %s", moduleID, version)
+}
+
+// TagsSyncConfig allows customizing how git tags are synced with versions.yaml
+type TagsSyncConfig struct {
+	RepositoryRoot string
+	CheckOnly      bool // Only verify the tags, report missing ones instead of creating them
+}
+
+// SyncGitTags creates the missing git tags of the released versions of all the modules using git tags
+// and verifies that existing ones point to the commit from versions.yaml. Pending autoreleases and
+// init entries have no tag.
+func SyncGitTags(config *TagsSyncConfig) error {
+	moduleInventory, err := inventory.InventorizeRepo(config.RepositoryRoot)
+	if err != nil {
+		return err
+	}
+
+	var syncErrs error
+	for _, module := range moduleInventory.ModuleInventory.Modules {
+		versions := module.GetVersions()
+		for _, version := range versions.ReleasedVersions {
+			if version.CommitID == modules.InitRef || version.CommitID == AutoReleaseHash {
+				continue
+			}
+			err := syncGitTag(config, &module, versions, version)
+			syncErrs = errors.Join(syncErrs, err)
+		}
+	}
+	return syncErrs
+}
+
+func syncGitTag(config *TagsSyncConfig, module *modules.KaeterModule, versions *modules.Versions, version *modules.VersionMetadata) error {
+	tagName, err := gitTagName(config.RepositoryRoot, versions, &GitTagData{
+		ModuleID: versions.ID,
+		Path:     gitTagPath(module.ModulePath),
+		Version:  version.Number.String(),
+		Commit:   version.CommitID,
+	})
+	if err != nil || tagName == "" {
+		return err
+	}
+
+	message := gitTagMessage(versions.ID, version.Number.String())
+	created, err := ensureGitTag(config.RepositoryRoot, tagName, version.CommitID, message, !config.CheckOnly)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", versions.ID, version.Number, err)
+	}
+	log.Info("Tag in sync", "moduleID", versions.ID, "tag", tagName, "created", created)
+	return nil
+}
@@ -0,0 +1,202 @@
+package actions
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-ch/kaeter/git"
+	"github.com/open-ch/kaeter/mocks"
+	"github.com/open-ch/kaeter/modules"
+)
+
+func TestGitTagName(t *testing.T) {
+	var tests = []struct {
+		name           string
+		annotation     string
+		configTemplate string
+		rootModule     bool
+		expectedTag    string
+		expectError    bool
+	}{
+		{
+			name: "No tag without template",
+		},
+		{
+			name:        "Module template",
+			annotation:  "{{.Path}}/v{{.Version}}",
+			expectedTag: "path/to/module/v1.2.3",
+		},
+		{
+			name:        "Module template for a module at the repository root",
+			annotation:  "{{.Path}}/v{{.Version}}",
+			rootModule:  true,
+			expectedTag: "v1.2.3",
+		},
+		{
+			name:           "Repository template",
+			configTemplate: "{{.ModuleID}}/v{{.Version}}",
+			expectedTag:    "ch.open.kaeter/unit-test/v1.2.3",
+		},
+		{
+			name:           "Module template overrides repository template",
+			annotation:     "v{{.Version}}",
+			configTemplate: "{{.ModuleID}}/v{{.Version}}",
+			expectedTag:    "v1.2.3",
+		},
+		{
+			name:        "Fails for unknown field",
+			annotation:  "{{.Name}}/v{{.Version}}",
+			expectError: true,
+		},
+		{
+			name:        "Fails for invalid tag",
+			annotation:  "{{.ModuleID}} {{.Version}}",
+			expectError: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+			viper.Set(gitTagViperKey, tc.configTemplate)
+			versions := &modules.Versions{ID: "ch.open.kaeter:unit-test", Metadata: &modules.Metadata{Annotations: map[string]string{}}}
+			if tc.annotation != "" {
+				versions.Metadata.Annotations[GitTagAnnotation] = tc.annotation
+			}
+
+			modulePath := "path/to/module"
+			if tc.rootModule {
+				modulePath = "."
+			}
+
+			tagName, err := gitTagName(t.TempDir(), versions, &GitTagData{
+				ModuleID: versions.ID,
+				Path:     gitTagPath(modulePath),
+				Version:  "1.2.3",
+				Commit:   "deadbeef",
+			})
+
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedTag, tagName)
+		})
+	}
+}
+
+func TestRunModuleReleaseGitTag(t *testing.T) {
+	for _, dryRun := range []bool{false, true} {
+		testFolder, _ := mocks.CreateMockRepo(t)
+		modulePath, commitHash := mocks.CreateKaeterModule(t, testFolder, &mocks.KaeterModuleConfig{
+			Path:         "module",
+			Makefile:     mocks.EmptyMakefileContent,
+			VersionsYAML: mocks.EmptyVersionsYAML,
+		})
+		moduleRelease := &ModuleRelease{
+			DryRun:           dryRun,
+			SkipCheckout:     true,
+			RepositoryRoot:   testFolder,
+			ReleaseTarget:    ReleaseTarget{ModuleID: "ch.open:unit-test", Version: "1.0.0"},
+			VersionsYAMLPath: filepath.Join(modulePath, "versions.yaml"),
+			VersionsData: &modules.Versions{
+				ID: "ch.open:unit-test",
+				ReleasedVersions: []*modules.VersionMetadata{
+					{Number: modules.NewVersion(1, 0, 0), CommitID: commitHash},
+				},
+				Metadata: &modules.Metadata{
+					Annotations: map[string]string{GitTagAnnotation: "{{.Path}}/v{{.Version}}"},
+				},
+			},
+		}
+
+		err := RunModuleRelease(t.Context(), moduleRelease)
+
+		assert.NoError(t, err)
+		taggedHash, err := git.ResolveRevision(testFolder, "refs/tags/module/v1.0.0^{commit}")
+		if dryRun {
+			assert.Error(t, err, "dry runs must not create tags")
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, commitHash, taggedHash)
+		// Releasing again keeps the existing tag
+		assert.NoError(t, RunModuleRelease(t.Context(), moduleRelease))
+	}
+}
+
+func TestRunModuleReleaseInvalidGitTag(t *testing.T) {
+	testFolder, _ := mocks.CreateMockRepo(t)
+	modulePath, commitHash := mocks.CreateKaeterModule(t, testFolder, &mocks.KaeterModuleConfig{
+		Path:         "module",
+		Makefile:     mocks.TouchMakefileContent,
+		VersionsYAML: mocks.EmptyVersionsYAML,
+	})
+	moduleRelease := &ModuleRelease{
+		SkipCheckout:     true,
+		RepositoryRoot:   testFolder,
+		ReleaseTarget:    ReleaseTarget{ModuleID: "ch.open:unit-test", Version: "1.0.0"},
+		VersionsYAMLPath: filepath.Join(modulePath, "versions.yaml"),
+		VersionsData: &modules.Versions{
+			ID: "ch.open:unit-test",
+			ReleasedVersions: []*modules.VersionMetadata{
+				{Number: modules.NewVersion(1, 0, 0), CommitID: commitHash},
+			},
+			Metadata: &modules.Metadata{
+				Annotations: map[string]string{GitTagAnnotation: "{{.Path}} v{{.Version}}"},
+			},
+		},
+	}
+
+	err := RunModuleRelease(t.Context(), moduleRelease)
+
+	assert.Error(t, err)
+	assert.NoFileExists(t, filepath.Join(modulePath, "build"), "no step runs with an invalid git tag")
+	assert.NoFileExists(t, filepath.Join(modulePath, "release"), "no step runs with an invalid git tag")
+}
+
+func TestSyncGitTags(t *testing.T) {
+	testFolder, _ := mocks.CreateMockRepo(t)
+	metadataYAML := "\nmetadata:\n  annotations:\n    " + GitTagAnnotation + ": \"{{.Path}}/v{{.Version}}\"\n"
+	modulePath, firstCommit := mocks.CreateKaeterModule(t, testFolder, &mocks.KaeterModuleConfig{
+		Path:         "module",
+		Makefile:     mocks.EmptyMakefileContent,
+		VersionsYAML: mocks.EmptyVersionsYAML + metadataYAML,
+	})
+	secondCommit := mocks.CommitFileAndGetHash(t, modulePath, "main.go", "package main", "feat: add main")
+	releasedVersions := "\n  0.1.0: 2024-01-01T00:00:00Z|" + firstCommit +
+		"\n  0.2.0: 2024-02-01T00:00:00Z|" + secondCommit +
+		"\n  0.3.0: 2024-03-01T00:00:00Z|AUTORELEASE"
+	mocks.CommitFileAndGetHash(t, modulePath, "versions.yaml", mocks.EmptyVersionsYAML+releasedVersions+metadataYAML, "chore: release")
+
+	err := SyncGitTags(&TagsSyncConfig{RepositoryRoot: testFolder, CheckOnly: true})
+	assert.ErrorContains(t, err, "tag module/v0.1.0 is missing")
+
+	err = SyncGitTags(&TagsSyncConfig{RepositoryRoot: testFolder})
+	assert.NoError(t, err)
+	for tagName, expectedHash := range map[string]string{"module/v0.1.0": firstCommit, "module/v0.2.0": secondCommit} {
+		taggedHash, err := git.ResolveRevision(testFolder, "refs/tags/"+tagName+"^{commit}")
+		assert.NoError(t, err)
+		assert.Equal(t, expectedHash, taggedHash)
+	}
+	_, err = git.ResolveRevision(testFolder, "refs/tags/module/v0.0.0")
+	assert.Error(t, err, "init entries are not tagged")
+	_, err = git.ResolveRevision(testFolder, "refs/tags/module/v0.3.0")
+	assert.Error(t, err, "pending autoreleases are not tagged")
+	assert.NoError(t, SyncGitTags(&TagsSyncConfig{RepositoryRoot: testFolder, CheckOnly: true}))
+
+	// Existing tags pointing to another commit are reported and never moved
+	execOutput, err := git.TagAnnotated(testFolder, "module/v0.4.0", "wrong commit", firstCommit)
+	assert.NoError(t, err, execOutput)
+	mocks.CommitFileAndGetHash(t, modulePath, "versions.yaml",
+		mocks.EmptyVersionsYAML+releasedVersions+"\n  0.4.0: 2024-04-01T00:00:00Z|"+secondCommit+metadataYAML, "chore: release")
+	err = SyncGitTags(&TagsSyncConfig{RepositoryRoot: testFolder})
+	assert.ErrorContains(t, err, "tag module/v0.4.0 points to "+firstCommit)
+	taggedHash, err := git.ResolveRevision(testFolder, "refs/tags/module/v0.4.0^{commit}")
+	assert.NoError(t, err)
+	assert.Equal(t, firstCommit, taggedHash)
+}
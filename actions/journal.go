@@ -0,0 +1,207 @@
+package actions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/open-ch/kaeter/log"
+)
+
+// ReleaseState describes how far the release of a target went.
+type ReleaseState string
+
+// Possible states of a release target in the journal
+const (
+	ReleaseStatePending  ReleaseState = "pending"
+	ReleaseStateBuilt    ReleaseState = "built"
+	ReleaseStateTested   ReleaseState = "tested"
+	ReleaseStateReleased ReleaseState = "released"
+	ReleaseStateFailed   ReleaseState = "failed"
+)
+
+// ReleaseJournal records the state of each target of a release plan so that
+// an interrupted or failed release can be resumed without re-releasing
+// targets that already succeeded.
+type ReleaseJournal struct {
+	ReleaseCommit string                `json:"releaseCommit"`
+	DryRun        bool                  `json:"dryRun"`
+	Targets       []ReleaseJournalEntry `json:"targets"`
+	path          string
+	mutex         sync.Mutex // targets can be released concurrently
+}
+
+// ReleaseJournalEntry is the recorded state of a single release target.
+type ReleaseJournalEntry struct {
+	ModuleID  string       `json:"moduleId"`
+	Version   string       `json:"version"`
+	State     ReleaseState `json:"state"`
+	Step      string       `json:"step,omitempty"` // last step which succeeded
+	Error     string       `json:"error,omitempty"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+}
+
+// newReleaseJournal creates a journal with all targets of the plan pending.
+func newReleaseJournal(journalPath, releaseCommit string, dryRun bool, rp *ReleasePlan) *ReleaseJournal {
+	journal := &ReleaseJournal{
+		ReleaseCommit: releaseCommit,
+		DryRun:        dryRun,
+		Targets:       make([]ReleaseJournalEntry, len(rp.Releases)),
+		path:          journalPath,
+	}
+	now := time.Now().UTC()
+	for i, target := range rp.Releases {
+		journal.Targets[i] = ReleaseJournalEntry{
+			ModuleID:  target.ModuleID,
+			Version:   target.Version,
+			State:     ReleaseStatePending,
+			UpdatedAt: now,
+		}
+	}
+	return journal
+}
+
+// readReleaseJournal loads a journal previously saved to the given path.
+func readReleaseJournal(journalPath string) (*ReleaseJournal, error) {
+	bytes, err := os.ReadFile(journalPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read release journal: %w", err)
+	}
+	journal := &ReleaseJournal{}
+	err = json.Unmarshal(bytes, journal)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse release journal %s: %w", journalPath, err)
+	}
+	journal.path = journalPath
+	return journal, nil
+}
+
+// loadReleaseJournal creates a new journal for the plan or when resuming loads the
+// existing one, making sure it was recorded for the same release.
+func (releaseConfig *ReleaseConfig) loadReleaseJournal(rp *ReleasePlan) (*ReleaseJournal, error) {
+	if releaseConfig.JournalPath == "" {
+		if releaseConfig.Resume {
+			return nil, errors.New("a journal path is required to resume a release")
+		}
+		return nil, nil //nolint:nilnil // journaling is optional
+	}
+	if !releaseConfig.Resume {
+		journal := newReleaseJournal(releaseConfig.JournalPath, releaseConfig.headHash, releaseConfig.DryRun, rp)
+		return journal, journal.save()
+	}
+
+	journal, err := readReleaseJournal(releaseConfig.JournalPath)
+	if err != nil {
+		return nil, err
+	}
+	if journal.ReleaseCommit != releaseConfig.headHash || journal.DryRun != releaseConfig.DryRun {
+		return nil, fmt.Errorf("release journal %s was recorded for commit %s (dry-run: %t), cannot resume release of %s (dry-run: %t)",
+			releaseConfig.JournalPath, journal.ReleaseCommit, journal.DryRun, releaseConfig.headHash, releaseConfig.DryRun)
+	}
+	for _, target := range rp.Releases {
+		if journal.entryFor(target) == nil {
+			return nil, fmt.Errorf("release journal %s does not contain target %s, cannot resume", releaseConfig.JournalPath, target.Marshal())
+		}
+	}
+	log.Info("Resuming release from journal", "journal", releaseConfig.JournalPath)
+	return journal, nil
+}
+
+func (journal *ReleaseJournal) entryFor(target ReleaseTarget) *ReleaseJournalEntry {
+	for i := range journal.Targets {
+		if journal.Targets[i].ModuleID == target.ModuleID && journal.Targets[i].Version == target.Version {
+			return &journal.Targets[i]
+		}
+	}
+	return nil
+}
+
+// isCompleted returns true if the target does not need to run again, for dry runs
+// a target is complete once tested since the release target is never run.
+func (journal *ReleaseJournal) isCompleted(target ReleaseTarget) bool {
+	if journal == nil {
+		return false
+	}
+	journal.mutex.Lock()
+	defer journal.mutex.Unlock()
+	entry := journal.entryFor(target)
+	if entry == nil {
+		return false
+	}
+	if journal.DryRun {
+		return entry.State == ReleaseStateTested
+	}
+	return entry.State == ReleaseStateReleased
+}
+
+// update records the new state of the target and persists the journal.
+func (journal *ReleaseJournal) update(target ReleaseTarget, state ReleaseState, targetErr error) error {
+	return journal.updateEntry(target, func(entry *ReleaseJournalEntry) {
+		entry.State = state
+		entry.Error = ""
+		if targetErr != nil {
+			entry.Error = targetErr.Error()
+		}
+	})
+}
+
+// stepCompleted records that a step of the target succeeded, once the last step
+// succeeded the target is released, or tested for dry runs.
+func (journal *ReleaseJournal) stepCompleted(target ReleaseTarget, step string, last bool) error {
+	if journal == nil {
+		return nil
+	}
+	return journal.updateEntry(target, func(entry *ReleaseJournalEntry) {
+		entry.Step = step
+		entry.Error = ""
+		switch {
+		case last && journal.DryRun:
+			entry.State = ReleaseStateTested
+		case last:
+			entry.State = ReleaseStateReleased
+		case step == "build":
+			entry.State = ReleaseStateBuilt
+		case step == "test":
+			entry.State = ReleaseStateTested
+		}
+	})
+}
+
+func (journal *ReleaseJournal) updateEntry(target ReleaseTarget, change func(entry *ReleaseJournalEntry)) error {
+	if journal == nil {
+		return nil
+	}
+	journal.mutex.Lock()
+	defer journal.mutex.Unlock()
+	entry := journal.entryFor(target)
+	if entry == nil {
+		return fmt.Errorf("target %s is not part of the release journal", target.Marshal())
+	}
+	change(entry)
+	entry.UpdatedAt = time.Now().UTC()
+	return journal.save()
+}
+
+// save writes the journal to a temporary file then renames it so that
+// a crash while saving does not leave a truncated journal behind.
+func (journal *ReleaseJournal) save() error {
+	bytes, err := json.MarshalIndent(journal, "", "    ")
+	if err != nil {
+		return fmt.Errorf("unable to serialize release journal: %w", err)
+	}
+	tmpFile, err := os.CreateTemp(filepath.Dir(journal.path), ".kaeter-journal-*")
+	if err != nil {
+		return fmt.Errorf("unable to save release journal: %w", err)
+	}
+	_, err = tmpFile.Write(bytes)
+	closeErr := tmpFile.Close()
+	if err = errors.Join(err, closeErr); err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return fmt.Errorf("unable to save release journal: %w", err)
+	}
+	return os.Rename(tmpFile.Name(), journal.path)
+}
@@ -0,0 +1,150 @@
+package actions
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-ch/kaeter/mocks"
+)
+
+const failingMakefileContent = ".PHONY: build test release\nbuild:\n\texit 1\ntest:\n\ttouch test\nrelease:\n\ttouch release"
+
+func TestReleaseJournalLifecycle(t *testing.T) {
+	journalPath := filepath.Join(mocks.CreateTmpFolder(t), "journal.json")
+	target := ReleaseTarget{ModuleID: "ch.open.kaeter:unit-test", Version: "1.0.0"}
+	plan := &ReleasePlan{Releases: []ReleaseTarget{target}}
+
+	journal := newReleaseJournal(journalPath, "deadbeef", false, plan)
+	assert.NoError(t, journal.save())
+	assert.False(t, journal.isCompleted(target))
+
+	assert.NoError(t, journal.update(target, ReleaseStateTested, nil))
+	assert.False(t, journal.isCompleted(target))
+	assert.NoError(t, journal.update(target, ReleaseStateFailed, errors.New("release failed")))
+
+	loaded, err := readReleaseJournal(journalPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "deadbeef", loaded.ReleaseCommit)
+	assert.Equal(t, ReleaseStateFailed, loaded.Targets[0].State)
+	assert.Equal(t, "release failed", loaded.Targets[0].Error)
+
+	assert.NoError(t, loaded.update(target, ReleaseStateReleased, nil))
+	assert.True(t, loaded.isCompleted(target))
+	assert.Empty(t, loaded.Targets[0].Error)
+
+	unknownTarget := ReleaseTarget{ModuleID: "ch.open.kaeter:other", Version: "1.0.0"}
+	assert.Error(t, loaded.update(unknownTarget, ReleaseStateReleased, nil))
+	assert.False(t, loaded.isCompleted(unknownTarget))
+}
+
+func TestReleaseJournalDryRunCompletion(t *testing.T) {
+	journalPath := filepath.Join(mocks.CreateTmpFolder(t), "journal.json")
+	target := ReleaseTarget{ModuleID: "ch.open.kaeter:unit-test", Version: "1.0.0"}
+	journal := newReleaseJournal(journalPath, "deadbeef", true, &ReleasePlan{Releases: []ReleaseTarget{target}})
+
+	assert.NoError(t, journal.update(target, ReleaseStateBuilt, nil))
+	assert.False(t, journal.isCompleted(target))
+	assert.NoError(t, journal.update(target, ReleaseStateTested, nil))
+	assert.True(t, journal.isCompleted(target))
+}
+
+func TestReleaseJournalStepCompleted(t *testing.T) {
+	var tests = []struct {
+		name           string
+		dryRun         bool
+		steps          []string
+		expectedStates []ReleaseState
+	}{
+		{
+			name:           "Default steps",
+			steps:          []string{"build", "test", "release"},
+			expectedStates: []ReleaseState{ReleaseStateBuilt, ReleaseStateTested, ReleaseStateReleased},
+		},
+		{
+			name:           "Custom steps keep the state until the last",
+			steps:          []string{"lint", "package", "publish"},
+			expectedStates: []ReleaseState{ReleaseStatePending, ReleaseStatePending, ReleaseStateReleased},
+		},
+		{
+			name:           "Last dry run step completes as tested",
+			dryRun:         true,
+			steps:          []string{"lint", "package"},
+			expectedStates: []ReleaseState{ReleaseStatePending, ReleaseStateTested},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			journalPath := filepath.Join(mocks.CreateTmpFolder(t), "journal.json")
+			target := ReleaseTarget{ModuleID: "ch.open.kaeter:unit-test", Version: "1.0.0"}
+			journal := newReleaseJournal(journalPath, "deadbeef", tc.dryRun, &ReleasePlan{Releases: []ReleaseTarget{target}})
+
+			for i, step := range tc.steps {
+				assert.NoError(t, journal.stepCompleted(target, step, i == len(tc.steps)-1))
+				assert.Equal(t, tc.expectedStates[i], journal.Targets[0].State)
+				assert.Equal(t, step, journal.Targets[0].Step)
+			}
+			assert.True(t, journal.isCompleted(target))
+		})
+	}
+}
+
+func TestRunReleasesResume(t *testing.T) {
+	commitMessage := `chore(release): 2 modules
+
+Release-Plan: ch.open.kaeter:module-a:0.1.0
+Release-Plan: ch.open.kaeter:module-b:0.1.0
+`
+	versionsA := mocks.GetEmptyVersionsYaml(t, "ch.open.kaeter:module-a") + "\n  0.1.0: 1970-01-01T00:00:00Z|eeeeee"
+	versionsB := mocks.GetEmptyVersionsYaml(t, "ch.open.kaeter:module-b") + "\n  0.1.0: 1970-01-01T00:00:00Z|eeeeee"
+	testFolder, _ := mocks.CreateMockRepo(t)
+	moduleA, _ := mocks.CreateKaeterModule(t, testFolder, &mocks.KaeterModuleConfig{
+		Path:         "module-a",
+		Makefile:     mocks.TouchMakefileContent,
+		VersionsYAML: versionsA,
+	})
+	moduleB, _ := mocks.CreateKaeterModule(t, testFolder, &mocks.KaeterModuleConfig{
+		Path:                  "module-b",
+		Makefile:              failingMakefileContent,
+		VersionsYAML:          versionsB,
+		OverrideCommitMessage: commitMessage,
+	})
+	journalPath := filepath.Join(mocks.CreateTmpFolder(t), "journal.json")
+	releaseConfig := func(resume bool) *ReleaseConfig {
+		return &ReleaseConfig{
+			RepositoryRoot:  testFolder,
+			RepositoryTrunk: "origin/main",
+			SkipCheckout:    true,
+			JournalPath:     journalPath,
+			Resume:          resume,
+		}
+	}
+
+	err := RunReleases(t.Context(), releaseConfig(false))
+	assert.Error(t, err)
+	assert.FileExists(t, filepath.Join(moduleA, "release"))
+	journal, err := readReleaseJournal(journalPath)
+	assert.NoError(t, err)
+	assert.Equal(t, ReleaseStateReleased, journal.Targets[0].State)
+	assert.Equal(t, ReleaseStateFailed, journal.Targets[1].State)
+
+	// Fix module b and remove module a artifacts to detect if it runs again
+	mocks.CreateMockFile(t, moduleB, "Makefile", mocks.TouchMakefileContent)
+	assert.NoError(t, os.Remove(filepath.Join(moduleA, "release")))
+
+	err = RunReleases(t.Context(), releaseConfig(true))
+	assert.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(moduleA, "release"))
+	assert.FileExists(t, filepath.Join(moduleB, "release"))
+	journal, err = readReleaseJournal(journalPath)
+	assert.NoError(t, err)
+	assert.Equal(t, ReleaseStateReleased, journal.Targets[1].State)
+
+	// Resuming a dry run from a real release journal is refused
+	dryRunConfig := releaseConfig(true)
+	dryRunConfig.DryRun = true
+	assert.Error(t, RunReleases(t.Context(), dryRunConfig))
+}
@@ -0,0 +1,153 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/open-ch/kaeter/git"
+	"github.com/open-ch/kaeter/hooks"
+	"github.com/open-ch/kaeter/log"
+	"github.com/open-ch/kaeter/modules"
+)
+
+// lifecycleHookContext holds what lifecycle hooks are told about the release of the
+// latest version of the module they run for.
+type lifecycleHookContext struct {
+	versions      *modules.Versions
+	repoRoot      string // hooks run from the root of the repository checkout
+	modulePath    string
+	releaseCommit string
+	dryRun        bool
+}
+
+// run runs the named hook if the module defines one. Positional hooks get the release context (see releaseEnvironment)
+// and KAETER_HOOK as environment variables, JSON hooks get the same environment and a hooks.Request on stdin.
+// The error of a failed release is passed to the hook if not nil. Modules without the hook are not affected.
+func (hookCtx *lifecycleHookContext) run(ctx context.Context, hookName string, releaseErr error) error {
+	if hookCtx == nil || !hooks.HasHook(hookName, hookCtx.versions) {
+		return nil
+	}
+	versions := hookCtx.versions
+	protocol, err := hooks.Protocol(hookName, versions)
+	if err != nil {
+		return err
+	}
+	env, err := releaseEnvironment(versions, hookCtx.releaseCommit, hookCtx.dryRun, hookCtx.repoRoot, hookCtx.modulePath)
+	if err != nil {
+		return err
+	}
+	env = append(env, "KAETER_HOOK="+hookName)
+	if releaseErr != nil {
+		env = append(env, "KAETER_RELEASE_ERROR="+releaseErr.Error())
+	}
+
+	log.Info("Running hook", "hook", hookName, "moduleID", versions.ID, "protocol", protocol)
+	if protocol == hooks.ProtocolJSON {
+		request, err := hookCtx.request(releaseErr)
+		if err != nil {
+			return err
+		}
+		response, err := hooks.RunJSONHook(ctx, hookName, versions, hookCtx.repoRoot, request, env)
+		logHookMessages(hookName, versions.ID, response)
+		if err != nil {
+			return fmt.Errorf("%s hook of %s failed: %w", hookName, versions.ID, err)
+		}
+		return nil
+	}
+
+	output, err := hooks.RunHookWithEnv(ctx, hookName, versions, hookCtx.repoRoot, nil, env)
+	if err != nil {
+		return fmt.Errorf("%s hook of %s failed: %w", hookName, versions.ID, err)
+	}
+	if output != "" {
+		log.Info("Hook output", "hook", hookName, "moduleID", versions.ID, "output", output)
+	}
+	return nil
+}
+
+// request returns the document passed to JSON hooks, with the changes since the previous version.
+func (hookCtx *lifecycleHookContext) request(releaseErr error) (*hooks.Request, error) {
+	relativePath, err := relativeModulePath(hookCtx.repoRoot, hookCtx.modulePath)
+	if err != nil {
+		return nil, err
+	}
+	releasedVersions := hookCtx.versions.ReleasedVersions
+	previousCommit := ""
+	if len(releasedVersions) > 1 {
+		previousCommit = releasedVersions[len(releasedVersions)-2].CommitID
+	}
+
+	request := hooks.NewRequest(hookCtx.versions, relativePath)
+	request.Target = &hooks.RequestTarget{
+		Version: releasedVersions[len(releasedVersions)-1].Number.String(),
+		Commit:  hookCtx.releaseCommit,
+		DryRun:  hookCtx.dryRun,
+	}
+	request.Changeset, err = moduleChangeset(hookCtx.repoRoot, relativePath, previousCommit, hookCtx.releaseCommit)
+	if err != nil {
+		return nil, err
+	}
+	if releaseErr != nil {
+		request.Error = releaseErr.Error()
+	}
+	return request, nil
+}
+
+// runPrePrepareHook runs the pre-prepare hook for the release about to be requested, the new
+// version must already be the latest of versions.
+func runPrePrepareHook(ctx context.Context, versions *modules.Versions, releaseCommit, repoRoot, modulePath string) error {
+	hookCtx := &lifecycleHookContext{
+		versions:      versions,
+		repoRoot:      repoRoot,
+		modulePath:    modulePath,
+		releaseCommit: releaseCommit,
+	}
+	return hookCtx.run(ctx, hooks.PrePrepare, nil)
+}
+
+// moduleChangeset returns the commits and files changed in the module (path relative to the repository root)
+// between two commits, nil if there is no previous release commit to compare to.
+// Pending autoreleases are compared up to HEAD.
+func moduleChangeset(repoRoot, relativeModulePath, since, until string) (*hooks.Changeset, error) {
+	if since == "" || since == modules.InitRef || since == AutoReleaseHash {
+		return nil, nil
+	}
+	if until == AutoReleaseHash {
+		until = "HEAD"
+	}
+	changeset := &hooks.Changeset{Since: since, Until: until, Commits: []string{}, Files: []string{}}
+
+	commitLog, err := git.LogOneLine(repoRoot, since+".."+until, relativeModulePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list commits of %s since %s: %w", relativeModulePath, since, err)
+	}
+	for line := range strings.Lines(commitLog) {
+		if line = strings.TrimSpace(line); line != "" {
+			changeset.Commits = append(changeset.Commits, line)
+		}
+	}
+
+	changedFiles, err := git.DiffNameStatus(repoRoot, since, until)
+	if err != nil {
+		return nil, err
+	}
+	for file := range changedFiles {
+		if relativeModulePath == "." || strings.HasPrefix(file, relativeModulePath+"/") {
+			changeset.Files = append(changeset.Files, file)
+		}
+	}
+	slices.Sort(changeset.Files)
+	return changeset, nil
+}
+
+// logHookMessages logs the messages a JSON hook responded with.
+func logHookMessages(hookName, moduleID string, response *hooks.Response) {
+	if response == nil {
+		return
+	}
+	for _, message := range response.Messages {
+		log.Info("Hook message", "hook", hookName, "moduleID", moduleID, "message", message)
+	}
+}
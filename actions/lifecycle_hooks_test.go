@@ -0,0 +1,210 @@
+package actions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-ch/kaeter/mocks"
+	"github.com/open-ch/kaeter/modules"
+)
+
+// recordingHookContent appends the hook name, version and error (if any) to hooks.log in the repository root
+const recordingHookContent = "#!/bin/sh\necho \"$KAETER_HOOK $KAETER_VERSION $KAETER_RELEASE_ERROR\" >> hooks.log\n"
+
+func createRecordingHook(t *testing.T, repoRoot, name, content string) {
+	t.Helper()
+	assert.NoError(t, os.MkdirAll(filepath.Join(repoRoot, "hooks"), 0700))
+	//nolint:gosec // the hook needs to be executable
+	assert.NoError(t, os.WriteFile(filepath.Join(repoRoot, "hooks", name), []byte(content), 0700))
+}
+
+func TestRunModuleReleaseLifecycleHooks(t *testing.T) {
+	var tests = []struct {
+		name          string
+		makefile      string
+		preRelease    string
+		expectedLog   string
+		expectedError bool
+	}{
+		{
+			name:        "Runs pre-release and post-release hooks around the steps",
+			makefile:    mocks.TouchMakefileContent,
+			preRelease:  recordingHookContent,
+			expectedLog: "pre-release 1.0.0 \npost-release 1.0.0 \n",
+		},
+		{
+			name:          "Runs on-release-failure hook when a step fails",
+			makefile:      failingMakefileContent,
+			preRelease:    recordingHookContent,
+			expectedLog:   "pre-release 1.0.0 \non-release-failure 1.0.0 failed 'build' target on module",
+			expectedError: true,
+		},
+		{
+			name:          "Failing pre-release hook aborts the release",
+			makefile:      mocks.TouchMakefileContent,
+			preRelease:    "#!/bin/sh\nexit 1\n",
+			expectedLog:   "on-release-failure 1.0.0 pre-release hook of ch.open:unit-test failed",
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repoRoot := mocks.CreateTmpFolder(t)
+			modulePath := filepath.Join(repoRoot, "module")
+			assert.NoError(t, os.Mkdir(modulePath, 0700))
+			mocks.CreateMockFile(t, modulePath, "Makefile", tc.makefile)
+			createRecordingHook(t, repoRoot, "pre-release.sh", tc.preRelease)
+			createRecordingHook(t, repoRoot, "record.sh", recordingHookContent)
+			moduleRelease := &ModuleRelease{
+				SkipCheckout:   true,
+				RepositoryRoot: repoRoot,
+				ReleaseTarget: ReleaseTarget{
+					ModuleID: "ch.open:unit-test",
+					Version:  "1.0.0",
+				},
+				VersionsYAMLPath: filepath.Join(modulePath, "versions.yaml"),
+				VersionsData: &modules.Versions{
+					ID: "ch.open:unit-test",
+					ReleasedVersions: []*modules.VersionMetadata{
+						{Number: modules.NewVersion(1, 0, 0), CommitID: "deadbeef"},
+					},
+					Metadata: &modules.Metadata{
+						Annotations: map[string]string{
+							"open.ch/kaeter-hook/pre-release":        "hooks/pre-release.sh",
+							"open.ch/kaeter-hook/post-release":       "hooks/record.sh",
+							"open.ch/kaeter-hook/on-release-failure": "hooks/record.sh",
+						},
+					},
+				},
+			}
+
+			err := RunModuleRelease(t.Context(), moduleRelease)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			hooksLog, err := os.ReadFile(filepath.Join(repoRoot, "hooks.log"))
+			assert.NoError(t, err)
+			assert.Contains(t, string(hooksLog), tc.expectedLog)
+			if tc.preRelease != recordingHookContent {
+				assert.NoFileExists(t, filepath.Join(modulePath, "build"), "steps must not run if pre-release fails")
+			}
+		})
+	}
+}
+
+func TestPrepareReleasePrePrepareHook(t *testing.T) {
+	var tests = []struct {
+		name          string
+		hookContent   string
+		expectedError bool
+	}{
+		{
+			name:        "Prepares the release once the hook succeeds",
+			hookContent: recordingHookContent,
+		},
+		{
+			name:          "Failing hook aborts before versions.yaml is modified",
+			hookContent:   "#!/bin/sh\nexit 1\n",
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			versionsYAML := mocks.EmptyVersionsYAML + "\nmetadata:\n  annotations:\n    open.ch/kaeter-hook/pre-prepare: hooks/pre-prepare.sh\n"
+			testFolder, _ := mocks.CreateKaeterRepo(t, &mocks.KaeterModuleConfig{
+				Makefile:     mocks.EmptyMakefileContent,
+				VersionsYAML: versionsYAML,
+			})
+			createRecordingHook(t, testFolder, "pre-prepare.sh", tc.hookContent)
+			config := &PrepareReleaseConfig{
+				BumpType:       modules.BumpPatch,
+				ModulePaths:    []string{testFolder},
+				RepositoryRef:  "main",
+				RepositoryRoot: testFolder,
+				SkipLint:       true,
+			}
+
+			err := PrepareRelease(t.Context(), config)
+
+			versions, readErr := os.ReadFile(filepath.Join(testFolder, "versions.yaml"))
+			assert.NoError(t, readErr)
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Equal(t, versionsYAML, string(versions))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Contains(t, string(versions), "0.0.1:")
+			hooksLog, err := os.ReadFile(filepath.Join(testFolder, "hooks.log"))
+			assert.NoError(t, err)
+			assert.Equal(t, "pre-prepare 0.0.1 \n", string(hooksLog))
+		})
+	}
+}
+
+func TestModuleChangeset(t *testing.T) {
+	testFolder, _ := mocks.CreateMockRepo(t)
+	modulePath, releaseCommit := mocks.CreateKaeterModule(t, testFolder, &mocks.KaeterModuleConfig{
+		Path:         "module",
+		Makefile:     mocks.EmptyMakefileContent,
+		VersionsYAML: mocks.EmptyVersionsYAML,
+	})
+	mocks.CommitFileAndGetHash(t, modulePath, "main.go", "package main", "feat: add main")
+	mocks.CommitFileAndGetHash(t, testFolder, "other.txt", "other", "chore: outside of module")
+	headCommit := mocks.CommitFileAndGetHash(t, modulePath, "README.md", "# Module", "docs: add readme")
+
+	changeset, err := moduleChangeset(testFolder, "module", releaseCommit, headCommit)
+
+	assert.NoError(t, err)
+	assert.Equal(t, releaseCommit, changeset.Since)
+	assert.Equal(t, headCommit, changeset.Until)
+	assert.Len(t, changeset.Commits, 2)
+	assert.Contains(t, changeset.Commits[0], "docs: add readme")
+	assert.Contains(t, changeset.Commits[1], "feat: add main")
+	assert.Equal(t, []string{"module/README.md", "module/main.go"}, changeset.Files)
+
+	for _, since := range []string{"", modules.InitRef, AutoReleaseHash} {
+		changeset, err = moduleChangeset(testFolder, "module", since, headCommit)
+		assert.NoError(t, err)
+		assert.Nil(t, changeset, "no changeset without previous release for %s", since)
+	}
+}
+
+func TestLifecycleHookRequestSymlinkedModulePath(t *testing.T) {
+	testFolder, _ := mocks.CreateMockRepo(t)
+	modulePath, releaseCommit := mocks.CreateKaeterModule(t, testFolder, &mocks.KaeterModuleConfig{
+		Path:         "module",
+		Makefile:     mocks.EmptyMakefileContent,
+		VersionsYAML: mocks.EmptyVersionsYAML,
+	})
+	headCommit := mocks.CommitFileAndGetHash(t, modulePath, "main.go", "package main", "feat: add main")
+	// The module is reached through a symlink while the repository root has symlinks resolved, as reported by git
+	linkPath := filepath.Join(mocks.CreateTmpFolder(t), "link")
+	assert.NoError(t, os.Symlink(testFolder, linkPath))
+	resolvedRoot, err := filepath.EvalSymlinks(testFolder)
+	assert.NoError(t, err)
+	hookCtx := &lifecycleHookContext{
+		versions: &modules.Versions{
+			ID: "ch.open:unit-test",
+			ReleasedVersions: []*modules.VersionMetadata{
+				{Number: modules.NewVersion(0, 1, 0), CommitID: releaseCommit},
+				{Number: modules.NewVersion(0, 2, 0), CommitID: headCommit},
+			},
+		},
+		repoRoot:      resolvedRoot,
+		modulePath:    filepath.Join(linkPath, "module"),
+		releaseCommit: headCommit,
+	}
+
+	request, err := hookCtx.request(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "module", request.Module.Path)
+	assert.Equal(t, []string{"module/main.go"}, request.Changeset.Files)
+}
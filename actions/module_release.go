@@ -1,34 +1,65 @@
 package actions
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/open-ch/kaeter/git"
+	"github.com/open-ch/kaeter/hooks"
+	"github.com/open-ch/kaeter/lint"
 	"github.com/open-ch/kaeter/log"
 	"github.com/open-ch/kaeter/makefiles"
 	"github.com/open-ch/kaeter/modules"
 )
 
+// TargetTimeoutAnnotationPrefix allows modules to limit how long each make target may run,
+// as a go duration, i.e. open.ch/kaeter-timeout/test: 30m
+const TargetTimeoutAnnotationPrefix = "open.ch/kaeter-timeout/"
+
+// EnvAnnotationPrefix allows modules to export additional environment variables to
+// their make targets, i.e. open.ch/kaeter-env/REGISTRY: registry.example.com
+const EnvAnnotationPrefix = "open.ch/kaeter-env/"
+
+// envNamePattern matches valid names of environment variables added through annotations
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`) //nolint:gochecknoglobals
+
 // ModuleRelease allows defining the parameters
 // for a single module release.
 type ModuleRelease struct {
-	CheckoutRestoreHash string
-	DryRun              bool
-	ReleaseTarget       ReleaseTarget
-	RepositoryTrunk     string
-	SkipCheckout        bool
-	VersionsData        *modules.Versions
-	VersionsYAMLPath    string
+	DryRun           bool
+	LogDir           string    // Optional folder to save the output of each target to
+	Output           io.Writer // Receives the output of the targets, each line prefixed with [moduleID:target]
+	ReleaseTarget    ReleaseTarget
+	RepositoryRoot   string // Exported to the targets when releasing from the current checkout (SkipCheckout)
+	RepositoryTrunk  string
+	SkipCheckout     bool          // Run the targets in the module folder rather than a worktree of the release commit
+	TargetTimeout    time.Duration // Default timeout of each make target, 0 for none, modules can override it with annotations
+	VersionsData     *modules.Versions
+	VersionsYAMLPath string
+	// onStep is called after each make target ran, successfully or not,
+	// last is true for the last step of the release (or of the dry run)
+	onStep func(step StepResult, last bool)
 }
 
 // RunModuleRelease performs a release (possibly dry-run or snapshot)
-// based on the ModuleRelease config and handles calling the make targets.
+// based on the ModuleRelease config and handles calling the make targets
+// configured as steps of the module release pipeline (build, test, release by default).
+// Unless SkipCheckout is set the targets run in a temporary git worktree of the release commit,
+// the repository checkout is never modified.
+// Make targets are interrupted if the context is cancelled or they time out, the worktree is still removed.
+// The pre-release, post-release and on-release-failure hooks of the module run around the steps,
+// modules using git tags get their release commit tagged before the post-release hook runs.
 // Note this only supports releasing the latest version from versions.yaml.
-//
-//nolint:cyclop
-func RunModuleRelease(moduleRelease *ModuleRelease) error {
+func RunModuleRelease(ctx context.Context, moduleRelease *ModuleRelease) error {
 	versionsData := moduleRelease.VersionsData
 
 	if moduleRelease.ReleaseTarget.ModuleID != versionsData.ID {
@@ -44,51 +75,338 @@ func RunModuleRelease(moduleRelease *ModuleRelease) error {
 			moduleRelease.ReleaseTarget.Marshal(), latestReleaseVersion.Number.String(), moduleRelease.VersionsYAMLPath)
 	}
 	modulePath := filepath.Dir(moduleRelease.VersionsYAMLPath)
-	makefileName, err := makefiles.DetectModuleMakefile(modulePath)
+	// TODO ideally refactor the commit selection (latestReleaseVersion above) and ValidateCommitIsOnTrunk outside
+	// of the release helper.
+	releaseCommitHash := latestReleaseVersion.CommitID
+	if !moduleRelease.SkipCheckout {
+		trunkBranch := strings.ReplaceAll(moduleRelease.RepositoryTrunk, "origin/", "")
+		if err := git.ValidateCommitIsOnTrunk(modulePath, trunkBranch, releaseCommitHash); err != nil {
+			return fmt.Errorf("invalid release commit:  %w", err)
+		}
+	}
+
+	pipeline, err := versionsData.GetReleasePipeline()
 	if err != nil {
 		return err
 	}
-	if !moduleRelease.SkipCheckout {
-		// TODO ideally refactor the commit selection (latestReleaseVersion above) and ValidateCommitIsOnTrunk outside
-		// of the release helper.
-		releaseCommitHash := latestReleaseVersion.CommitID
-		trunkBranch := strings.ReplaceAll(moduleRelease.RepositoryTrunk, "origin/", "")
+	steps := pipeline.Steps
+	if moduleRelease.DryRun {
+		steps = pipeline.DryRunSteps()
+		log.Warn("Dry run mode is enabled: not releasing anything.", "moduleID", versionsData.ID,
+			"skippedSteps", pipeline.Steps[len(steps):])
+	}
 
-		if err = git.ValidateCommitIsOnTrunk(modulePath, trunkBranch, releaseCommitHash); err != nil {
-			return fmt.Errorf("invalid release commit:  %w", err)
+	// The tag is rendered up front so an invalid template fails before anything is released
+	gitTag, err := moduleRelease.releaseGitTag(modulePath)
+	if err != nil {
+		return err
+	}
+
+	hookCtx, err := moduleRelease.hookContext(modulePath)
+	if err != nil {
+		return err
+	}
+	err = hookCtx.run(ctx, hooks.PreRelease, nil)
+	if err == nil {
+		if moduleRelease.SkipCheckout {
+			err = moduleRelease.runTargets(ctx, steps, moduleRelease.RepositoryRoot, modulePath)
+		} else {
+			err = moduleRelease.runInWorktree(ctx, steps, modulePath, releaseCommitHash)
 		}
+	}
+	if err == nil {
+		err = moduleRelease.createGitTag(gitTag)
+	}
+	if err != nil {
+		return errors.Join(err, hookCtx.run(ctx, hooks.OnReleaseFailure, err))
+	}
+	err = hookCtx.run(ctx, hooks.PostRelease, nil)
+	if err != nil {
+		return err
+	}
+	log.Info("Done.", "moduleID", moduleRelease.ReleaseTarget.ModuleID)
+	return nil
+}
 
-		log.Info("Checking out commit hash of version", "version", latestReleaseVersion.Number, "ref", releaseCommitHash)
-		output, err := git.Checkout(modulePath, releaseCommitHash)
+// releaseGitTag renders and validates the git tag of the release, also for dry runs,
+// nil if the module does not use git tags.
+func (moduleRelease *ModuleRelease) releaseGitTag(modulePath string) (*releaseTag, error) {
+	if gitTagTemplate(moduleRelease.VersionsData) == "" {
+		return nil, nil
+	}
+	repoRoot := moduleRelease.RepositoryRoot
+	if repoRoot == "" {
+		var err error
+		repoRoot, err = git.ShowTopLevel(modulePath)
 		if err != nil {
-			log.Error("Failed to checkout release commit", "gitRef", releaseCommitHash, "gitOutput", output)
-			return err
+			return nil, fmt.Errorf("unable to find repository to tag release in: %w", err)
+		}
+	}
+	releaseCommit, err := moduleRelease.releaseCommit(modulePath)
+	if err != nil {
+		return nil, err
+	}
+	return newReleaseTag(repoRoot, modulePath, moduleRelease.VersionsData, releaseCommit)
+}
+
+// createGitTag tags the release commit if the module uses git tags, nothing is tagged for dry runs.
+// The tag is created once all the steps succeeded, if that fails it can be created with kaeter tags sync.
+func (moduleRelease *ModuleRelease) createGitTag(gitTag *releaseTag) error {
+	if gitTag == nil {
+		return nil
+	}
+	if moduleRelease.DryRun {
+		log.Info("Dry run mode is enabled: not creating git tag.", "moduleID", moduleRelease.VersionsData.ID, "tag", gitTag.name)
+		return nil
+	}
+	return gitTag.create(moduleRelease.VersionsData)
+}
+
+// hookContext returns the context of the lifecycle hooks, which run from the root of the repository
+// checkout (never the release worktree), nil if the module has no release hooks.
+func (moduleRelease *ModuleRelease) hookContext(modulePath string) (*lifecycleHookContext, error) {
+	hasHooks := false
+	for _, hookName := range []string{hooks.PreRelease, hooks.PostRelease, hooks.OnReleaseFailure} {
+		hasHooks = hasHooks || hooks.HasHook(hookName, moduleRelease.VersionsData)
+	}
+	if !hasHooks {
+		return nil, nil
+	}
+	hookRoot := moduleRelease.RepositoryRoot
+	if hookRoot == "" {
+		var err error
+		hookRoot, err = git.ShowTopLevel(modulePath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find repository root to run hooks from: %w", err)
 		}
 	}
-	err = makefiles.RunTarget(modulePath, makefileName, "build", moduleRelease.ReleaseTarget.Version)
+	releaseCommit, err := moduleRelease.releaseCommit(modulePath)
+	if err != nil {
+		return nil, err
+	}
+	return &lifecycleHookContext{
+		versions:      moduleRelease.VersionsData,
+		repoRoot:      hookRoot,
+		modulePath:    modulePath,
+		releaseCommit: releaseCommit,
+		dryRun:        moduleRelease.DryRun,
+	}, nil
+}
+
+// runInWorktree runs the targets in a temporary worktree of the release commit, leaving
+// the repository checkout untouched so that several modules can be released at once.
+// If kaeter is interrupted the worktree is left behind in the temp folder, it is pruned by the
+// next release once the folder is deleted, the repository itself is never affected.
+func (moduleRelease *ModuleRelease) runInWorktree(ctx context.Context, steps []string, modulePath, releaseCommitHash string) error {
+	repoRoot, err := git.ShowTopLevel(modulePath)
+	if err != nil {
+		return fmt.Errorf("unable to find repository of module %s: %w", modulePath, err)
+	}
+	// The top level is reported with symlinks resolved, resolve the module path to compute the relative path
+	resolvedModulePath, err := filepath.EvalSymlinks(modulePath)
 	if err != nil {
 		return err
 	}
-	err = makefiles.RunTarget(modulePath, makefileName, "test", moduleRelease.ReleaseTarget.Version)
+	relativeModulePath, err := filepath.Rel(repoRoot, resolvedModulePath)
 	if err != nil {
 		return err
 	}
-	if moduleRelease.DryRun {
-		log.Warn("Dry run mode is enabled: not releasing anything.")
-	} else {
-		err = makefiles.RunTarget(modulePath, makefileName, "release", moduleRelease.ReleaseTarget.Version)
+	worktreePath, err := os.MkdirTemp("", "kaeter-release-")
+	if err != nil {
+		return fmt.Errorf("unable to create release worktree folder: %w", err)
+	}
+
+	log.Info("Creating worktree at commit hash of version", "version", moduleRelease.ReleaseTarget.Version,
+		"ref", releaseCommitHash, "worktree", worktreePath)
+	output, err := git.WorktreeAdd(repoRoot, worktreePath, releaseCommitHash)
+	if err != nil {
+		log.Error("Failed to create release worktree", "gitRef", releaseCommitHash, "gitOutput", output)
+		return errors.Join(err, os.RemoveAll(worktreePath))
+	}
+	err = moduleRelease.runTargets(ctx, steps, worktreePath, filepath.Join(worktreePath, relativeModulePath))
+	output, removeErr := git.WorktreeRemove(repoRoot, worktreePath)
+	if removeErr != nil {
+		log.Error("Failed to remove release worktree", "worktree", worktreePath, "gitOutput", output)
+		return errors.Join(err, removeErr)
+	}
+	return err
+}
+
+// runTargets runs the steps (make targets) in order in the module folder, repoRoot is the
+// root of the checkout (or worktree) the module is part of.
+func (moduleRelease *ModuleRelease) runTargets(ctx context.Context, steps []string, repoRoot, modulePath string) error {
+	makefileName, err := makefiles.DetectModuleMakefile(modulePath)
+	if err != nil {
+		return err
+	}
+	env, err := moduleRelease.environment(repoRoot, modulePath)
+	if err != nil {
+		return err
+	}
+	notesPath, err := moduleRelease.releaseNotesFile(modulePath)
+	if err != nil {
+		return err
+	}
+	if notesPath != "" {
+		defer os.Remove(notesPath)
+	}
+	env = append(env, "KAETER_RELEASE_NOTES="+notesPath)
+	for i, step := range steps {
+		err = moduleRelease.runTarget(ctx, modulePath, makefileName, step, env, i == len(steps)-1)
 		if err != nil {
 			return err
 		}
 	}
-	if !moduleRelease.SkipCheckout {
-		output, err := git.ResetHard(modulePath, moduleRelease.CheckoutRestoreHash)
+	return nil
+}
+
+func (moduleRelease *ModuleRelease) runTarget(ctx context.Context, modulePath, makefileName, makeTarget string, env []string, last bool) error {
+	timeout, err := moduleRelease.targetTimeout(makeTarget)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	run := &makefiles.TargetRun{
+		ModulePath: modulePath,
+		Makefile:   makefileName,
+		Target:     makeTarget,
+		Version:    moduleRelease.ReleaseTarget.Version,
+		Env:        env,
+		Output:     moduleRelease.Output,
+		LogPrefix:  fmt.Sprintf("[%s:%s] ", moduleRelease.ReleaseTarget.ModuleID, makeTarget),
+		Timeout:    timeout,
+	}
+	if moduleRelease.LogDir != "" {
+		run.LogPath = filepath.Join(moduleRelease.LogDir, targetLogFileName(moduleRelease.ReleaseTarget, makeTarget))
+	}
+	err = run.Run(ctx)
+	if moduleRelease.onStep != nil {
+		moduleRelease.onStep(newStepResult(makeTarget, time.Since(start), err), last)
+	}
+	return err
+}
+
+// targetTimeout returns the timeout of the make target from the module annotations
+// or the default timeout if the module does not configure one.
+func (moduleRelease *ModuleRelease) targetTimeout(makeTarget string) (time.Duration, error) {
+	if moduleRelease.VersionsData.Metadata == nil {
+		return moduleRelease.TargetTimeout, nil
+	}
+	value, found := moduleRelease.VersionsData.Metadata.Annotations[TargetTimeoutAnnotationPrefix+makeTarget]
+	if !found {
+		return moduleRelease.TargetTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout for '%s' target of %s: %w", makeTarget, moduleRelease.VersionsData.ID, err)
+	}
+	return timeout, nil
+}
+
+// targetLogFileName returns a file name for the output of a target, i.e. ch.open_unit-test_1.0.0_build.log
+func targetLogFileName(releaseTarget ReleaseTarget, makeTarget string) string {
+	name := strings.NewReplacer(":", "_", "/", "_").Replace(releaseTarget.ModuleID)
+	return fmt.Sprintf("%s_%s_%s.log", name, releaseTarget.Version, makeTarget)
+}
+
+// environment returns the KAETER_* variables describing the release for the make targets
+// along with the variables the module adds through annotations.
+func (moduleRelease *ModuleRelease) environment(repoRoot, modulePath string) ([]string, error) {
+	releaseCommit, err := moduleRelease.releaseCommit(modulePath)
+	if err != nil {
+		return nil, err
+	}
+	return releaseEnvironment(moduleRelease.VersionsData, releaseCommit, moduleRelease.DryRun, repoRoot, modulePath)
+}
+
+// releaseNotesFile writes the notes of the released version from the changelog of the module to a
+// temporary file for the targets, the path is empty if the changelog has none for the version.
+func (moduleRelease *ModuleRelease) releaseNotesFile(modulePath string) (string, error) {
+	notes, err := lint.GetReleaseNotes(modulePath, moduleRelease.ReleaseTarget.Version)
+	if err != nil {
+		log.Warn("No release notes available to the targets", "moduleID", moduleRelease.ReleaseTarget.ModuleID, "error", err)
+		return "", nil
+	}
+	notesFile, err := os.CreateTemp("", "kaeter-release-notes-*.md")
+	if err != nil {
+		return "", fmt.Errorf("unable to create release notes file: %w", err)
+	}
+	_, err = notesFile.WriteString(notes.Notes + "\n")
+	if err = errors.Join(err, notesFile.Close()); err != nil {
+		return "", errors.Join(fmt.Errorf("unable to write release notes file: %w", err), os.Remove(notesFile.Name()))
+	}
+	return notesFile.Name(), nil
+}
+
+// releaseCommit returns the commit of the latest version, autoreleases are released
+// from the commit which merged the request (HEAD of the module checkout).
+func (moduleRelease *ModuleRelease) releaseCommit(modulePath string) (string, error) {
+	releasedVersions := moduleRelease.VersionsData.ReleasedVersions
+	releaseCommit := releasedVersions[len(releasedVersions)-1].CommitID
+	if releaseCommit != AutoReleaseHash {
+		return releaseCommit, nil
+	}
+	headHash, err := git.ResolveRevision(modulePath, "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve release commit of autorelease: %w", err)
+	}
+	return headHash, nil
+}
+
+// relativeModulePath returns the path of the module relative to the repository root with symlinks resolved
+// in both, git reports the top level of the repository with symlinks resolved but module paths are not.
+func relativeModulePath(repoRoot, modulePath string) (string, error) {
+	resolvedRepoRoot, err := filepath.EvalSymlinks(repoRoot)
+	if err != nil {
+		return "", err
+	}
+	resolvedModulePath, err := filepath.EvalSymlinks(modulePath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Rel(resolvedRepoRoot, resolvedModulePath)
+}
+
+// releaseEnvironment returns the KAETER_* variables describing the release of the latest version
+// of the module from the given commit, along with the variables the module adds through annotations.
+func releaseEnvironment(versions *modules.Versions, releaseCommit string, dryRun bool, repoRoot, modulePath string) ([]string, error) {
+	releaseVersion := versions.ReleasedVersions[len(versions.ReleasedVersions)-1]
+	previousVersion := ""
+	if len(versions.ReleasedVersions) > 1 {
+		previousVersion = versions.ReleasedVersions[len(versions.ReleasedVersions)-2].Number.String()
+	}
+
+	env := []string{
+		"KAETER_MODULE_ID=" + versions.ID,
+		"KAETER_VERSION=" + releaseVersion.Number.String(),
+		"KAETER_PREVIOUS_VERSION=" + previousVersion,
+		"KAETER_RELEASE_COMMIT=" + releaseCommit,
+		"KAETER_RELEASE_TAGS=" + strings.Join(releaseVersion.Tags, ","),
+		"KAETER_DRY_RUN=" + strconv.FormatBool(dryRun),
+	}
+	if repoRoot != "" {
+		relativeModulePath, err := relativeModulePath(repoRoot, modulePath)
 		if err != nil {
-			log.Error("Failed to checkout back to head", "headRef", moduleRelease.CheckoutRestoreHash, "gitOutput", output)
-			return err
+			return nil, err
 		}
-		log.Warn("Repository HEAD reset in detached head state to", "commit", moduleRelease.CheckoutRestoreHash)
+		env = append(env, "KAETER_REPO_ROOT="+repoRoot, "KAETER_MODULE_PATH="+relativeModulePath)
 	}
-	log.Info("Done.")
-	return nil
+
+	if versions.Metadata == nil {
+		return env, nil
+	}
+	var annotatedEnv []string
+	for annotation, value := range versions.Metadata.Annotations {
+		name, found := strings.CutPrefix(annotation, EnvAnnotationPrefix)
+		if !found {
+			continue
+		}
+		if !envNamePattern.MatchString(name) || strings.HasPrefix(name, "KAETER_") {
+			return nil, fmt.Errorf("invalid environment variable name '%s' in annotation of %s, KAETER_ variables are reserved",
+				name, versions.ID)
+		}
+		annotatedEnv = append(annotatedEnv, name+"="+value)
+	}
+	slices.Sort(annotatedEnv)
+	return append(env, annotatedEnv...), nil
 }
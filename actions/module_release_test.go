@@ -1,12 +1,16 @@
 package actions
 
 import (
+	"bytes"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/open-ch/kaeter/git"
 	"github.com/open-ch/kaeter/mocks"
 	"github.com/open-ch/kaeter/modules"
 )
@@ -18,10 +22,9 @@ func TestRunModuleRelease(t *testing.T) {
 	mocks.CreateMockFile(t, testFolder, "versions.yaml", "")
 	mocks.CreateMockFile(t, testFolder, "Makefile", dryrunMakefileContent)
 	moduleRelease := &ModuleRelease{
-		CheckoutRestoreHash: "eeeeeeee",
-		DryRun:              true,
-		SkipCheckout:        true,
-		RepositoryTrunk:     "origin/main",
+		DryRun:          true,
+		SkipCheckout:    true,
+		RepositoryTrunk: "origin/main",
 		ReleaseTarget: ReleaseTarget{
 			ModuleID: "ch.open:unit-test",
 			Version:  "1.0.0",
@@ -41,7 +44,326 @@ func TestRunModuleRelease(t *testing.T) {
 		},
 	}
 
-	err := RunModuleRelease(moduleRelease)
+	err := RunModuleRelease(t.Context(), moduleRelease)
 
 	assert.NoError(t, err)
 }
+
+func TestRunModuleReleaseLogs(t *testing.T) {
+	testFolder := mocks.CreateTmpFolder(t)
+	logDir := filepath.Join(mocks.CreateTmpFolder(t), "logs")
+	mocks.CreateMockFile(t, testFolder, "Makefile", dryrunMakefileContent)
+	output := &bytes.Buffer{}
+	moduleRelease := &ModuleRelease{
+		DryRun:       true,
+		LogDir:       logDir,
+		Output:       output,
+		SkipCheckout: true,
+		ReleaseTarget: ReleaseTarget{
+			ModuleID: "ch.open:unit-test",
+			Version:  "1.0.0",
+		},
+		VersionsYAMLPath: filepath.Join(testFolder, "versions.yaml"),
+		VersionsData: &modules.Versions{
+			ID: "ch.open:unit-test",
+			ReleasedVersions: []*modules.VersionMetadata{
+				{Number: modules.NewVersion(1, 0, 0), CommitID: "deadbeef"},
+			},
+		},
+	}
+
+	err := RunModuleRelease(t.Context(), moduleRelease)
+
+	assert.NoError(t, err)
+	assert.Contains(t, output.String(), "[ch.open:unit-test:build] building\n")
+	assert.Contains(t, output.String(), "[ch.open:unit-test:test] testing\n")
+	buildLog, err := os.ReadFile(filepath.Join(logDir, "ch.open_unit-test_1.0.0_build.log"))
+	assert.NoError(t, err)
+	assert.Equal(t, "building\n", string(buildLog))
+	assert.FileExists(t, filepath.Join(logDir, "ch.open_unit-test_1.0.0_test.log"))
+}
+
+func TestRunModuleReleasePipelineSteps(t *testing.T) {
+	var tests = []struct {
+		name          string
+		dryRun        bool
+		expectedSteps []string
+	}{
+		{
+			name:          "Runs all configured steps in order",
+			expectedSteps: []string{"lint", "package", "publish"},
+		},
+		{
+			name:          "Dry run stops at the cut-off step",
+			dryRun:        true,
+			expectedSteps: []string{"lint", "package"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testFolder := mocks.CreateTmpFolder(t)
+			mocks.CreateMockFile(t, testFolder, "Makefile",
+				".PHONY: lint package publish\nlint:\n\t@echo lint\npackage:\n\t@echo package\npublish:\n\t@echo publish")
+			var steps []string
+			moduleRelease := &ModuleRelease{
+				DryRun:       tc.dryRun,
+				SkipCheckout: true,
+				Output:       &bytes.Buffer{},
+				ReleaseTarget: ReleaseTarget{
+					ModuleID: "ch.open:unit-test",
+					Version:  "1.0.0",
+				},
+				VersionsYAMLPath: filepath.Join(testFolder, "versions.yaml"),
+				VersionsData: &modules.Versions{
+					ID: "ch.open:unit-test",
+					ReleasedVersions: []*modules.VersionMetadata{
+						{Number: modules.NewVersion(1, 0, 0), CommitID: "deadbeef"},
+					},
+					Release: &modules.ReleasePipeline{
+						Steps:       []string{"lint", "package", "publish"},
+						DryRunUntil: "package",
+					},
+				},
+				onStep: func(step StepResult, _ bool) {
+					steps = append(steps, step.Name)
+				},
+			}
+
+			err := RunModuleRelease(t.Context(), moduleRelease)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedSteps, steps)
+		})
+	}
+}
+
+func TestRunModuleReleaseInWorktree(t *testing.T) {
+	testFolder, _ := mocks.CreateMockRepo(t)
+	modulePath, commitHash := mocks.CreateKaeterModule(t, testFolder, &mocks.KaeterModuleConfig{
+		Path:         "module",
+		Makefile:     ".PHONY: build test release\nbuild:\n\ttouch $(CURDIR)/../../built\ntest:\nrelease:",
+		VersionsYAML: mocks.GetEmptyVersionsYaml(t, "ch.open:unit-test"),
+	})
+	headHash := mocks.CommitFileAndGetHash(t, testFolder, "later-change", "", "Later change")
+	// Local changes are not part of the release commit and must be preserved
+	mocks.CreateMockFile(t, modulePath, "Makefile", "local change")
+	worktreesFolder := mocks.CreateTmpFolder(t)
+	t.Setenv("TMPDIR", worktreesFolder)
+	moduleRelease := &ModuleRelease{
+		DryRun:          true,
+		RepositoryTrunk: "origin/main",
+		ReleaseTarget: ReleaseTarget{
+			ModuleID: "ch.open:unit-test",
+			Version:  "1.0.0",
+		},
+		VersionsYAMLPath: filepath.Join(modulePath, "versions.yaml"),
+		VersionsData: &modules.Versions{
+			ID: "ch.open:unit-test",
+			ReleasedVersions: []*modules.VersionMetadata{
+				{Number: modules.NewVersion(1, 0, 0), CommitID: commitHash},
+			},
+		},
+	}
+
+	err := RunModuleRelease(t.Context(), moduleRelease)
+
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(worktreesFolder, "built"))
+	currentHead, err := git.ResolveRevision(testFolder, "HEAD")
+	assert.NoError(t, err)
+	assert.Equal(t, headHash, currentHead)
+	makefile, err := os.ReadFile(filepath.Join(modulePath, "Makefile"))
+	assert.NoError(t, err)
+	assert.Equal(t, "local change", string(makefile))
+	worktrees, err := os.ReadDir(worktreesFolder)
+	assert.NoError(t, err)
+	assert.Len(t, worktrees, 1, "the worktree should be removed")
+}
+
+func TestModuleReleaseTargetTimeout(t *testing.T) {
+	var tests = []struct {
+		name            string
+		annotations     map[string]string
+		expectedTimeout time.Duration
+		hasError        bool
+	}{
+		{
+			name:            "Default timeout without annotations",
+			expectedTimeout: time.Hour,
+		},
+		{
+			name:            "Timeout from annotation",
+			annotations:     map[string]string{"open.ch/kaeter-timeout/build": "5m"},
+			expectedTimeout: 5 * time.Minute,
+		},
+		{
+			name:            "Annotation of other target",
+			annotations:     map[string]string{"open.ch/kaeter-timeout/test": "5m"},
+			expectedTimeout: time.Hour,
+		},
+		{
+			name:        "Invalid annotation",
+			annotations: map[string]string{"open.ch/kaeter-timeout/build": "5 minutes"},
+			hasError:    true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			moduleRelease := &ModuleRelease{
+				TargetTimeout: time.Hour,
+				VersionsData:  &modules.Versions{ID: "ch.open:unit-test"},
+			}
+			if tc.annotations != nil {
+				moduleRelease.VersionsData.Metadata = &modules.Metadata{Annotations: tc.annotations}
+			}
+
+			timeout, err := moduleRelease.targetTimeout("build")
+
+			if tc.hasError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedTimeout, timeout)
+		})
+	}
+}
+
+func TestModuleReleaseEnvironment(t *testing.T) {
+	versionsData := func(annotations map[string]string) *modules.Versions {
+		versions := &modules.Versions{
+			ID: "ch.open:unit-test",
+			ReleasedVersions: []*modules.VersionMetadata{
+				{Number: modules.NewVersion(1, 0, 0), CommitID: "cafe"},
+				{Number: modules.NewVersion(1, 1, 0), CommitID: "deadbeef", Tags: []string{"lts", "stable"}},
+			},
+		}
+		if annotations != nil {
+			versions.Metadata = &modules.Metadata{Annotations: annotations}
+		}
+		return versions
+	}
+	var tests = []struct {
+		name         string
+		withRepoRoot bool
+		annotations  map[string]string
+		expectedEnv  []string
+		hasError     bool
+	}{
+		{
+			name: "Release context",
+			expectedEnv: []string{
+				"KAETER_MODULE_ID=ch.open:unit-test",
+				"KAETER_VERSION=1.1.0",
+				"KAETER_PREVIOUS_VERSION=1.0.0",
+				"KAETER_RELEASE_COMMIT=deadbeef",
+				"KAETER_RELEASE_TAGS=lts,stable",
+				"KAETER_DRY_RUN=true",
+			},
+		},
+		{
+			name:         "Repository root and module path",
+			withRepoRoot: true,
+			expectedEnv:  []string{"KAETER_MODULE_PATH=path/to/module"},
+		},
+		{
+			name: "Variables from annotations",
+			annotations: map[string]string{
+				"open.ch/kaeter-env/REGISTRY": "registry.example.com",
+				"open.ch/kaeter-env/_CHANNEL": "beta",
+				"open.ch/kaeter-hook/other":   "ignored",
+			},
+			expectedEnv: []string{"REGISTRY=registry.example.com", "_CHANNEL=beta"},
+		},
+		{
+			name:        "Invalid variable name",
+			annotations: map[string]string{"open.ch/kaeter-env/NOT-VALID": "value"},
+			hasError:    true,
+		},
+		{
+			name:        "Reserved variable name",
+			annotations: map[string]string{"open.ch/kaeter-env/KAETER_VERSION": "2.0.0"},
+			hasError:    true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			moduleRelease := &ModuleRelease{
+				DryRun:        true,
+				ReleaseTarget: ReleaseTarget{ModuleID: "ch.open:unit-test", Version: "1.1.0"},
+				VersionsData:  versionsData(tc.annotations),
+			}
+
+			repoRoot := t.TempDir()
+			modulePath := mocks.CreateMockFolder(t, repoRoot, "path/to/module")
+			if !tc.withRepoRoot {
+				repoRoot = ""
+			}
+
+			env, err := moduleRelease.environment(repoRoot, modulePath)
+
+			if tc.hasError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Subset(t, env, tc.expectedEnv)
+			if tc.withRepoRoot {
+				assert.Contains(t, env, "KAETER_REPO_ROOT="+repoRoot)
+			}
+		})
+	}
+}
+
+func TestRunModuleReleaseNotes(t *testing.T) {
+	var tests = []struct {
+		name          string
+		changelog     string
+		expectedNotes string
+	}{
+		{
+			name:          "Passes the notes of the version to the targets",
+			changelog:     "# CHANGELOG\n\n## 1.0.0 - 18.5.20\n\n- Initial release\n",
+			expectedNotes: "- Initial release\n",
+		},
+		{
+			name:      "Passes an empty path without notes for the version",
+			changelog: "# CHANGELOG\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testFolder := mocks.CreateTmpFolder(t)
+			mocks.CreateMockFile(t, testFolder, "CHANGELOG.md", tc.changelog)
+			mocks.CreateMockFile(t, testFolder, "Makefile",
+				".PHONY: build test release\nbuild:\ntest:\nrelease:\n\techo \"$$KAETER_RELEASE_NOTES\" > notes-path\n"+
+					"\tif [ -n \"$$KAETER_RELEASE_NOTES\" ]; then cp \"$$KAETER_RELEASE_NOTES\" notes.md; fi\n")
+			moduleRelease := &ModuleRelease{
+				SkipCheckout:     true,
+				ReleaseTarget:    ReleaseTarget{ModuleID: "ch.open:unit-test", Version: "1.0.0"},
+				VersionsYAMLPath: filepath.Join(testFolder, "versions.yaml"),
+				VersionsData: &modules.Versions{
+					ID: "ch.open:unit-test",
+					ReleasedVersions: []*modules.VersionMetadata{
+						{Number: modules.NewVersion(1, 0, 0), CommitID: "deadbeef"},
+					},
+				},
+			}
+
+			err := RunModuleRelease(t.Context(), moduleRelease)
+
+			assert.NoError(t, err)
+			notesPath, err := os.ReadFile(filepath.Join(testFolder, "notes-path"))
+			assert.NoError(t, err)
+			if tc.expectedNotes == "" {
+				assert.Equal(t, "\n", string(notesPath))
+				assert.NoFileExists(t, filepath.Join(testFolder, "notes.md"))
+				return
+			}
+			assert.NoFileExists(t, strings.TrimSpace(string(notesPath)), "the notes file should be removed after the release")
+			notes, err := os.ReadFile(filepath.Join(testFolder, "notes.md"))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedNotes, string(notes))
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-ch/kaeter/inventory"
+	"github.com/open-ch/kaeter/lint"
+	"github.com/open-ch/kaeter/log"
+	"github.com/open-ch/kaeter/modules"
+)
+
+// ReleasePlanNotes combines the release notes of all the modules of a release plan
+type ReleasePlanNotes struct {
+	Modules []ModuleReleaseNotes `json:"modules"`
+}
+
+// ModuleReleaseNotes are the release notes of a single target of a release plan
+type ModuleReleaseNotes struct {
+	ModuleID        string   `json:"moduleId"`
+	Path            string   `json:"path"`
+	Version         string   `json:"version"`
+	PreviousVersion string   `json:"previousVersion,omitempty"`
+	Notes           string   `json:"notes"`   // From the changelog, empty if it has none for the version
+	Commits         []string `json:"commits"` // Commits of the module since the previous release (git log --oneline)
+}
+
+// GetReleasePlanNotes gathers the changelog section of the version of each target of the release plan
+// along with the commits changing the module since its previous release.
+func GetReleasePlanNotes(repoRoot string, releasePlan *ReleasePlan) (*ReleasePlanNotes, error) {
+	moduleInventory, err := inventory.InventorizeRepo(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	planNotes := &ReleasePlanNotes{Modules: []ModuleReleaseNotes{}}
+	for _, target := range releasePlan.Releases {
+		module, err := moduleInventory.GetModule(target.ModuleID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find module of release target %s: %w", target.Marshal(), err)
+		}
+		moduleNotes, err := getModuleReleaseNotes(repoRoot, module, target)
+		if err != nil {
+			return nil, err
+		}
+		planNotes.Modules = append(planNotes.Modules, *moduleNotes)
+	}
+	return planNotes, nil
+}
+
+func getModuleReleaseNotes(repoRoot string, module *modules.KaeterModule, target ReleaseTarget) (*ModuleReleaseNotes, error) {
+	releasedVersions := module.GetVersions().ReleasedVersions
+	versionIndex := -1
+	for i, version := range releasedVersions {
+		if version.Number.String() == target.Version {
+			versionIndex = i
+		}
+	}
+	if versionIndex < 0 {
+		return nil, fmt.Errorf("version of release target %s not found in versions.yaml", target.Marshal())
+	}
+
+	moduleNotes := &ModuleReleaseNotes{
+		ModuleID: target.ModuleID,
+		Path:     module.ModulePath,
+		Version:  target.Version,
+		Commits:  []string{},
+	}
+	notes, err := lint.GetReleaseNotes(filepath.Join(repoRoot, module.ModulePath), target.Version)
+	if err != nil {
+		log.Warn("No release notes found", "moduleID", target.ModuleID, "error", err)
+	} else {
+		moduleNotes.Notes = notes.Notes
+	}
+
+	if versionIndex == 0 {
+		return moduleNotes, nil
+	}
+	previousVersion := releasedVersions[versionIndex-1]
+	moduleNotes.PreviousVersion = previousVersion.Number.String()
+	changeset, err := moduleChangeset(repoRoot, module.ModulePath, previousVersion.CommitID, releasedVersions[versionIndex].CommitID)
+	if err != nil {
+		return nil, err
+	}
+	if changeset != nil {
+		moduleNotes.Commits = changeset.Commits
+	}
+	return moduleNotes, nil
+}
+
+// ToJSON returns the notes as indented json
+func (planNotes *ReleasePlanNotes) ToJSON() (string, error) {
+	notesJSON, err := json.MarshalIndent(planNotes, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("could not marshal release notes to JSON: %w", err)
+	}
+	return string(notesJSON), nil
+}
+
+// ToMarkdown returns the notes as a markdown document with a section per module
+func (planNotes *ReleasePlanNotes) ToMarkdown() string {
+	var markdown strings.Builder
+	markdown.WriteString("# Release notes\n")
+	for _, moduleNotes := range planNotes.Modules {
+		fmt.Fprintf(&markdown, "\n## %s %s\n\n", moduleNotes.ModuleID, moduleNotes.Version)
+		if moduleNotes.Notes != "" {
+			markdown.WriteString(moduleNotes.Notes + "\n")
+		} else {
+			markdown.WriteString("_No release notes in the changelog._\n")
+		}
+		if len(moduleNotes.Commits) == 0 {
+			continue
+		}
+		fmt.Fprintf(&markdown, "\n### Commits since %s\n\n", moduleNotes.PreviousVersion)
+		for _, commit := range moduleNotes.Commits {
+			markdown.WriteString("- " + commit + "\n")
+		}
+	}
+	return markdown.String()
+}
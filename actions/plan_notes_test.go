@@ -0,0 +1,66 @@
+package actions
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-ch/kaeter/mocks"
+)
+
+func TestGetReleasePlanNotes(t *testing.T) {
+	versionsA := mocks.GetEmptyVersionsYaml(t, "ch.open.kaeter:module-a")
+	versionsB := mocks.GetEmptyVersionsYaml(t, "ch.open.kaeter:module-b")
+	testFolder, _ := mocks.CreateMockRepo(t)
+	moduleA, firstReleaseA := mocks.CreateKaeterModule(t, testFolder, &mocks.KaeterModuleConfig{
+		Path:         "module-a",
+		Makefile:     mocks.EmptyMakefileContent,
+		VersionsYAML: versionsA,
+		CHANGELOG:    "# CHANGELOG\n\n## 0.1.0 - 18.5.20\n\n- Initial release\n",
+	})
+	moduleB, releaseB := mocks.CreateKaeterModule(t, testFolder, &mocks.KaeterModuleConfig{
+		Path:         "module-b",
+		Makefile:     mocks.EmptyMakefileContent,
+		VersionsYAML: versionsB,
+	})
+	mocks.CommitFileAndGetHash(t, moduleA, "main.go", "package main", "feat: add main")
+	mocks.CommitFileAndGetHash(t, testFolder, "other.txt", "other", "chore: outside of modules")
+	secondReleaseA := mocks.CommitFileAndGetHash(t, moduleA, "CHANGELOG.md",
+		"# CHANGELOG\n\n## 0.2.0 - 19.5.20\n\n- Add main\n\n## 0.1.0 - 18.5.20\n\n- Initial release\n", "docs: changelog")
+	mocks.CreateMockFile(t, moduleA, "versions.yaml",
+		versionsA+"\n  0.1.0: 2020-05-18T00:00:00Z|"+firstReleaseA+"\n  0.2.0: 2020-05-19T00:00:00Z|"+secondReleaseA)
+	mocks.CreateMockFile(t, moduleB, "versions.yaml", versionsB+"\n  1.0.0: 2020-05-19T00:00:00Z|"+releaseB)
+	releasePlan := &ReleasePlan{Releases: []ReleaseTarget{
+		{ModuleID: "ch.open.kaeter:module-a", Version: "0.2.0"},
+		{ModuleID: "ch.open.kaeter:module-b", Version: "1.0.0"},
+	}}
+
+	planNotes, err := GetReleasePlanNotes(testFolder, releasePlan)
+
+	assert.NoError(t, err)
+	assert.Len(t, planNotes.Modules, 2)
+	notesA := planNotes.Modules[0]
+	assert.Equal(t, "ch.open.kaeter:module-a", notesA.ModuleID)
+	assert.Equal(t, "module-a", filepath.ToSlash(notesA.Path))
+	assert.Equal(t, "0.1.0", notesA.PreviousVersion)
+	assert.Equal(t, "- Add main", notesA.Notes)
+	assert.Len(t, notesA.Commits, 2)
+	assert.Contains(t, notesA.Commits[0], "docs: changelog")
+	assert.Contains(t, notesA.Commits[1], "feat: add main")
+	notesB := planNotes.Modules[1]
+	assert.Equal(t, "0.0.0", notesB.PreviousVersion)
+	assert.Empty(t, notesB.Notes, "module-b has no changelog entry")
+	assert.Empty(t, notesB.Commits, "no commits since INIT")
+
+	markdown := planNotes.ToMarkdown()
+	assert.Contains(t, markdown, "## ch.open.kaeter:module-a 0.2.0\n\n- Add main\n\n### Commits since 0.1.0\n")
+	assert.Contains(t, markdown, "## ch.open.kaeter:module-b 1.0.0\n\n_No release notes in the changelog._\n")
+	_, err = planNotes.ToJSON()
+	assert.NoError(t, err)
+
+	_, err = GetReleasePlanNotes(testFolder, SingleReleasePlan("ch.open.kaeter:module-a", "9.9.9"))
+	assert.ErrorContains(t, err, "not found in versions.yaml")
+	_, err = GetReleasePlanNotes(testFolder, SingleReleasePlan("ch.open.kaeter:unknown", "1.0.0"))
+	assert.Error(t, err)
+}
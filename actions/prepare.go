@@ -1,6 +1,7 @@
 package actions
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"time"
@@ -18,14 +19,15 @@ type PrepareReleaseConfig struct {
 	ModulePaths         []string
 	RepositoryRef       string
 	RepositoryRoot      string
+	ScaffoldChangelog   bool // Add an entry for the new version to the changelog, staged with versions.yaml
 	SkipLint            bool
 	Tags                *[]string // nil = don't change, empty slice = clear tags, non-empty = set tags
 	UserProvidedVersion string
 }
 
 // PrepareRelease will generate a release entry in versions.yaml and create a properly formatted
-// release commit
-func PrepareRelease(config *PrepareReleaseConfig) error {
+// release commit. The pre-prepare hook of each module runs before its versions.yaml is modified.
+func PrepareRelease(ctx context.Context, config *PrepareReleaseConfig) error {
 	releaseTargets := make([]ReleaseTarget, len(config.ModulePaths))
 
 	refTime := time.Now().UTC()
@@ -38,8 +40,15 @@ func PrepareRelease(config *PrepareReleaseConfig) error {
 
 	for i, modulePath := range config.ModulePaths {
 		var versions *modules.Versions
-		versions, err = config.bumpModule(modulePath, hash, &refTime)
+		var changelogPath string
+		versions, changelogPath, err = config.bumpModule(ctx, modulePath, hash, &refTime)
 		if err != nil {
+			if changelogPath != "" {
+				// The changelog entry was added but could not be staged
+				if resetErr := config.restoreVersions(modulePath, changelogPath); resetErr != nil {
+					log.Error("Unexpected error reverting change, manually edit versions.yaml and the changelog", "error", resetErr)
+				}
+			}
 			return err
 		}
 		releaseVersion := versions.ReleasedVersions[len(versions.ReleasedVersions)-1].Number.String()
@@ -53,7 +62,7 @@ func PrepareRelease(config *PrepareReleaseConfig) error {
 		err = config.lintKaeterModule(modulePath)
 		if err != nil {
 			log.Error("Error detected on module, reverting changes to version.yaml...")
-			resetErr := config.restoreVersions(modulePath)
+			resetErr := config.restoreVersions(modulePath, changelogPath)
 			if resetErr != nil {
 				log.Error(
 					"Unexpected error reverting change, manually edit versions.yaml to remove version",
@@ -85,39 +94,61 @@ func PrepareRelease(config *PrepareReleaseConfig) error {
 	return nil
 }
 
-func (config *PrepareReleaseConfig) bumpModule(modulePath, releaseHash string, refTime *time.Time) (*modules.Versions, error) {
+// bumpModule adds the release to versions.yaml and stages it, along with the changelog if an entry
+// is scaffolded for the release in which case the path of the changelog is returned.
+func (config *PrepareReleaseConfig) bumpModule(ctx context.Context, modulePath, releaseHash string, refTime *time.Time) (*modules.Versions, string, error) {
 	log.Info("Preparing module for bump", "modulePath", modulePath)
 	absVersionsPath, err := modules.GetVersionsFilePath(modulePath)
 	absModuleDir := filepath.Dir(absVersionsPath)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	versions, err := modules.ReadFromFile(absVersionsPath)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	log.Debug("versions file loaded for bump", "moduleId", versions.ID)
 	newReleaseMeta, err := versions.AddRelease(refTime, config.BumpType, config.UserProvidedVersion, releaseHash)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	applyTags(newReleaseMeta, config.Tags)
 
+	err = runPrePrepareHook(ctx, versions, releaseHash, config.RepositoryRoot, absModuleDir)
+	if err != nil {
+		return nil, "", err
+	}
+
 	log.Debug("saving new version to file", "newVersion", newReleaseMeta.Number.String(), "versionsYAML", absVersionsPath)
 	err = versions.SaveToFile(absVersionsPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed save versions.yaml: %w", err)
+		return nil, "", fmt.Errorf("failed save versions.yaml: %w", err)
 	}
 
 	log.Debug("staging file for commit", "versionsYAML", absVersionsPath)
 	output, err := git.Add(absModuleDir, filepath.Base(absVersionsPath))
 	if err != nil {
-		return nil, fmt.Errorf("failed to stage changes: %s\n%w", output, err)
+		return nil, "", fmt.Errorf("failed to stage changes: %s\n%w", output, err)
 	}
 
-	return versions, nil
+	if !config.ScaffoldChangelog {
+		return versions, "", nil
+	}
+	changelogPath, err := addChangelogEntry(config.RepositoryRoot, absModuleDir, versions, releaseHash, refTime)
+	if err != nil || changelogPath == "" {
+		return versions, "", err
+	}
+	relativeChangelogPath, err := filepath.Rel(absModuleDir, changelogPath)
+	if err != nil {
+		return versions, changelogPath, err
+	}
+	output, err = git.Add(absModuleDir, relativeChangelogPath)
+	if err != nil {
+		return versions, changelogPath, fmt.Errorf("failed to stage changes: %s\n%w", output, err)
+	}
+	return versions, changelogPath, nil
 }
 
 func (config *PrepareReleaseConfig) lintKaeterModule(modulePath string) error {
@@ -134,7 +165,8 @@ func (config *PrepareReleaseConfig) lintKaeterModule(modulePath string) error {
 	return nil
 }
 
-func (config *PrepareReleaseConfig) restoreVersions(modulePath string) error {
+// restoreVersions resets versions.yaml and the changelog, if an entry was added to it
+func (config *PrepareReleaseConfig) restoreVersions(modulePath, changelogPath string) error {
 	absVersionsPath, err := modules.GetVersionsFilePath(modulePath)
 	if err != nil {
 		return fmt.Errorf("unable to find path to version.yaml for reset: %w", err)
@@ -145,5 +177,13 @@ func (config *PrepareReleaseConfig) restoreVersions(modulePath string) error {
 		log.Debug("Failed reseting versions.yaml", "output", output)
 		return fmt.Errorf("failed to reset versions.yaml using git: %w", err)
 	}
+	if changelogPath == "" {
+		return nil
+	}
+	output, err = git.RestoreFile(config.RepositoryRoot, changelogPath)
+	if err != nil {
+		log.Debug("Failed reseting changelog", "output", output)
+		return fmt.Errorf("failed to reset changelog using git: %w", err)
+	}
 	return nil
 }
@@ -76,7 +76,7 @@ func TestPrepareRelease(t *testing.T) {
 				UserProvidedVersion: tc.manualVersion,
 			}
 
-			err := PrepareRelease(config)
+			err := PrepareRelease(t.Context(), config)
 
 			if tc.expectedFailure {
 				assert.Error(t, err)
@@ -137,7 +137,7 @@ func TestBumpModule(t *testing.T) {
 			}
 			refTime := time.Unix(42, 0)
 
-			versions, err := config.bumpModule(testFolder, "somegithash", &refTime)
+			versions, _, err := config.bumpModule(t.Context(), testFolder, "somegithash", &refTime)
 
 			assert.NoError(t, err)
 			releaseVersion := versions.ReleasedVersions[len(versions.ReleasedVersions)-1].Number.String()
@@ -145,3 +145,64 @@ func TestBumpModule(t *testing.T) {
 		})
 	}
 }
+
+func TestPrepareReleaseScaffoldChangelog(t *testing.T) {
+	testFolder, firstCommit := mocks.CreateKaeterRepo(t, &mocks.KaeterModuleConfig{
+		Makefile:          mocks.EmptyMakefileContent,
+		VersionsYAML:      mocks.EmptyVersionsYAML,
+		READMECreateEmpty: true,
+		CHANGELOG:         "# CHANGELOG\n\n## 0.1.0 - 01.01.24\n\n- Initial release\n",
+	})
+	mocks.CommitFileAndGetHash(t, testFolder, "versions.yaml",
+		mocks.EmptyVersionsYAML+"\n  0.1.0: 2024-01-01T00:00:00Z|"+firstCommit, "chore: release 0.1.0")
+	mocks.CommitFileAndGetHash(t, testFolder, "main.go", "package main", "feat: add main")
+	config := &PrepareReleaseConfig{
+		BumpType:          modules.BumpPatch,
+		ModulePaths:       []string{testFolder},
+		RepositoryRef:     "main",
+		RepositoryRoot:    testFolder,
+		ScaffoldChangelog: true,
+	}
+
+	err := PrepareRelease(t.Context(), config)
+
+	assert.NoError(t, err)
+	changelog, err := os.ReadFile(filepath.Join(testFolder, "CHANGELOG.md"))
+	assert.NoError(t, err)
+	assert.Regexp(t, `## 0\.1\.1 - \d{2}\.\d{2}\.\d{2}\n\n- feat: add main\n- chore: release 0\.1\.0\n\n## 0\.1\.0`, string(changelog))
+	status, err := git.StatusPorcelain(testFolder)
+	assert.NoError(t, err)
+	assert.Empty(t, status, "the changelog should be committed with versions.yaml")
+}
+
+func TestPrepareReleaseScaffoldDebianChangelog(t *testing.T) {
+	testFolder, _ := mocks.CreateMockRepo(t)
+	mocks.CreateMockFolder(t, testFolder, "module/debian")
+	modulePath, firstCommit := mocks.CreateKaeterModule(t, testFolder, &mocks.KaeterModuleConfig{
+		Path:              "module",
+		Makefile:          mocks.EmptyMakefileContent,
+		VersionsYAML:      mocks.EmptyVersionsYAML,
+		READMECreateEmpty: true,
+		CHANGELOGName:     "debian/changelog",
+		CHANGELOG:         "unit-test (0.1.0) unstable; urgency=medium\n\n  * Initial release\n\n -- Unit Test <unittest@example.ch>  Mon, 01 Jan 2024 10:00:00 +0000\n",
+	})
+	mocks.CommitFileAndGetHash(t, modulePath, "versions.yaml",
+		mocks.EmptyVersionsYAML+"\n  0.1.0: 2024-01-01T00:00:00Z|"+firstCommit, "chore: release 0.1.0")
+	config := &PrepareReleaseConfig{
+		BumpType:          modules.BumpPatch,
+		ModulePaths:       []string{modulePath},
+		RepositoryRef:     "main",
+		RepositoryRoot:    testFolder,
+		ScaffoldChangelog: true,
+	}
+
+	err := PrepareRelease(t.Context(), config)
+
+	assert.NoError(t, err)
+	changelog, err := os.ReadFile(filepath.Join(modulePath, "debian", "changelog"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(changelog), "(0.1.1)")
+	status, err := git.StatusPorcelain(testFolder)
+	assert.NoError(t, err)
+	assert.Empty(t, status, "the changelog should be committed with versions.yaml")
+}
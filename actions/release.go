@@ -1,7 +1,14 @@
 package actions
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/open-ch/kaeter/git"
 	"github.com/open-ch/kaeter/inventory"
@@ -12,19 +19,35 @@ import (
 // will handle the process
 type ReleaseConfig struct {
 	headHash             string
+	output               io.Writer
 	RepositoryRoot       string
 	RepositoryTrunk      string
 	ReleaseCommitMessage string
 	DryRun               bool // Replaces !really
 	SkipCheckout         bool // Replaces nocheckout
 	SkipModules          []string
+	JournalPath          string        // Optional path to record the state of each release target
+	Resume               bool          // Skip targets already completed according to the journal
+	ContinueOnError      bool          // Attempt all targets even if one fails
+	ReportJSONPath       string        // Optional path to write a JSON summary of the release
+	ReportJUnitPath      string        // Optional path to write a JUnit XML summary of the release
+	Parallel             int           // Maximum number of modules released at once
+	TargetTimeout        time.Duration // Default timeout of each make target, 0 for none
+	LogDir               string        // Optional folder to save the output of each module target to
 }
 
 // RunReleases attempts to release for the modules listed in the
 // commit's release plan for the given repository config
 // Note: this will return an error on the first release failure, skipping
-// any later releases but not roll back any successful ones.
-func RunReleases(releaseConfig *ReleaseConfig) error {
+// any later releases but not roll back any successful ones. Unless ContinueOnError
+// is set in which case all targets are attempted and the failures joined.
+// When a journal path is configured the state of each target is recorded
+// and a failed release can be resumed, skipping completed targets.
+// When report paths are configured a summary of each target is written once done.
+// Targets are released after the targets of the plan they depend on, with Parallel
+// greater than one independent targets are released concurrently.
+// Once the context is cancelled running targets are interrupted and no new target is started.
+func RunReleases(ctx context.Context, releaseConfig *ReleaseConfig) error {
 	err := releaseConfig.loadReleaseCommitInfo()
 	if err != nil {
 		return err
@@ -43,42 +66,126 @@ func RunReleases(releaseConfig *ReleaseConfig) error {
 	if err != nil {
 		return err
 	}
+	journal, err := releaseConfig.loadReleaseJournal(rp)
+	if err != nil {
+		return err
+	}
 
-	for _, releaseTarget := range rp.Releases {
-		skipReleaseTarget := false
-		for _, skipModuleID := range releaseConfig.SkipModules {
-			if releaseTarget.ModuleID == skipModuleID {
-				skipReleaseTarget = true
-				break
-			}
-		}
-		if skipReleaseTarget {
-			log.Info("Skipping module release", "moduleID", releaseTarget.ModuleID)
-			continue
+	if !releaseConfig.SkipCheckout {
+		// Forget about worktrees left behind by interrupted releases
+		if output, pruneErr := git.WorktreePrune(releaseConfig.RepositoryRoot); pruneErr != nil {
+			log.Warn("Failed to prune stale worktrees", "gitOutput", output, "error", pruneErr)
 		}
+	}
+	jobs, err := scheduleReleaseJobs(moduleIventory, rp.Releases)
+	if err != nil {
+		return err
+	}
 
-		targetModule, found := moduleIventory.Lookup[releaseTarget.ModuleID]
-		if !found {
-			return fmt.Errorf("could not locate module with id %s in repository living in %s",
-				releaseTarget.ModuleID, releaseConfig.RepositoryRoot)
-		}
-		versionsYAMLPath := targetModule.GetVersionsPath()
-		log.Info("Module found", "moduleID", releaseTarget.ModuleID, "path", versionsYAMLPath)
-
-		err = RunModuleRelease(&ModuleRelease{
-			CheckoutRestoreHash: releaseConfig.headHash,
-			DryRun:              releaseConfig.DryRun,
-			SkipCheckout:        releaseConfig.SkipCheckout,
-			ReleaseTarget:       releaseTarget,
-			RepositoryTrunk:     releaseConfig.RepositoryTrunk,
-			VersionsYAMLPath:    versionsYAMLPath,
-			VersionsData:        targetModule.GetVersions(),
-		})
-		if err != nil {
+	// Output lines of all targets are prefixed with the module and target, writes are serialized
+	// so that the lines of modules released in parallel are not mixed up.
+	releaseConfig.output = &syncWriter{writer: os.Stdout}
+	report := newReleaseReport(releaseConfig.headHash, releaseConfig.DryRun)
+	var releaseErrs error
+	var errsMutex sync.Mutex
+	addReleaseErr := func(err error) {
+		errsMutex.Lock()
+		defer errsMutex.Unlock()
+		releaseErrs = errors.Join(releaseErrs, err)
+	}
+	runReleaseJobs(ctx, jobs, releaseConfig.Parallel, releaseConfig.ContinueOnError,
+		func(job releaseJob) error {
+			releaseTarget := job.target
+			if slices.Contains(releaseConfig.SkipModules, releaseTarget.ModuleID) {
+				log.Info("Skipping module release", "moduleID", releaseTarget.ModuleID)
+				report.skipTarget(releaseTarget, "skipped by configuration")
+				return nil
+			}
+			if journal.isCompleted(releaseTarget) {
+				log.Info("Skipping module already completed according to journal", "moduleID", releaseTarget.ModuleID)
+				report.skipTarget(releaseTarget, "completed according to journal")
+				return nil
+			}
+
+			targetReport := report.startTarget(releaseTarget)
+			err := releaseConfig.releaseTarget(ctx, moduleIventory, journal, releaseTarget, targetReport)
+			targetReport.finish(err, releaseConfig.DryRun)
+			if err != nil {
+				err = errors.Join(err, journal.update(releaseTarget, ReleaseStateFailed, err))
+				if !releaseConfig.ContinueOnError {
+					addReleaseErr(err)
+					return err
+				}
+				log.Error("Release failed, continuing with next target", "moduleID", releaseTarget.ModuleID, "error", err)
+				addReleaseErr(fmt.Errorf("release of %s failed: %w", releaseTarget.Marshal(), err))
+			}
 			return err
-		}
+		},
+		func(job releaseJob, failedDependency ReleaseTarget) {
+			log.Error("Skipping module release, a dependency failed", "moduleID", job.target.ModuleID, "dependency", failedDependency.ModuleID)
+			report.skipTarget(job.target, fmt.Sprintf("dependency %s failed", failedDependency.Marshal()))
+			addReleaseErr(fmt.Errorf("release of %s skipped: dependency %s failed", job.target.Marshal(), failedDependency.Marshal()))
+		},
+	)
+	if ctx.Err() != nil {
+		addReleaseErr(fmt.Errorf("release interrupted: %w", context.Cause(ctx)))
 	}
-	return nil
+	report.finish()
+
+	return errors.Join(releaseErrs, releaseConfig.writeReports(report))
+}
+
+func (releaseConfig *ReleaseConfig) writeReports(report *ReleaseReport) error {
+	var errs error
+	if releaseConfig.ReportJSONPath != "" {
+		errs = errors.Join(errs, report.WriteJSON(releaseConfig.ReportJSONPath))
+		log.Info("Release report written", "path", releaseConfig.ReportJSONPath)
+	}
+	if releaseConfig.ReportJUnitPath != "" {
+		errs = errors.Join(errs, report.WriteJUnit(releaseConfig.ReportJUnitPath))
+		log.Info("Release JUnit report written", "path", releaseConfig.ReportJUnitPath)
+	}
+	return errs
+}
+
+func (releaseConfig *ReleaseConfig) releaseTarget(
+	ctx context.Context,
+	moduleIventory *inventory.Inventory,
+	journal *ReleaseJournal,
+	releaseTarget ReleaseTarget,
+	targetReport *TargetReport,
+) error {
+	targetModule, found := moduleIventory.Lookup[releaseTarget.ModuleID]
+	if !found {
+		return fmt.Errorf("could not locate module with id %s in repository living in %s",
+			releaseTarget.ModuleID, releaseConfig.RepositoryRoot)
+	}
+	versionsYAMLPath := targetModule.GetVersionsPath()
+	log.Info("Module found", "moduleID", releaseTarget.ModuleID, "path", versionsYAMLPath)
+
+	var journalErr error
+	err := RunModuleRelease(ctx, &ModuleRelease{
+		DryRun:           releaseConfig.DryRun,
+		LogDir:           releaseConfig.LogDir,
+		Output:           releaseConfig.output,
+		SkipCheckout:     releaseConfig.SkipCheckout,
+		TargetTimeout:    releaseConfig.TargetTimeout,
+		ReleaseTarget:    releaseTarget,
+		RepositoryRoot:   releaseConfig.RepositoryRoot,
+		RepositoryTrunk:  releaseConfig.RepositoryTrunk,
+		VersionsYAMLPath: versionsYAMLPath,
+		VersionsData:     targetModule.GetVersions(),
+		onStep: func(step StepResult, last bool) {
+			targetReport.Steps = append(targetReport.Steps, step)
+			if step.Error == "" {
+				journalErr = errors.Join(journalErr, journal.stepCompleted(releaseTarget, step.Name, last))
+			}
+		},
+	})
+	if journalErr != nil {
+		log.Error("Failed to record release progress in journal", "moduleID", releaseTarget.ModuleID, "error", journalErr)
+	}
+	return err
 }
 
 func (releaseConfig *ReleaseConfig) loadReleaseCommitInfo() error {
@@ -101,3 +208,15 @@ func (releaseConfig *ReleaseConfig) loadReleaseCommitInfo() error {
 	releaseConfig.ReleaseCommitMessage = headCommitMessage
 	return nil
 }
+
+// syncWriter serializes writes to the underlying writer.
+type syncWriter struct {
+	mutex  sync.Mutex
+	writer io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.writer.Write(p)
+}
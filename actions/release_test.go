@@ -1,6 +1,7 @@
 package actions
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -8,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/open-ch/kaeter/mocks"
+	"github.com/open-ch/kaeter/process"
 )
 
 func TestRunReleasesSkipModules(t *testing.T) {
@@ -65,7 +67,7 @@ versions:
 				SkipModules:     tc.skipModules,
 			}
 
-			err := RunReleases(releaseConfig)
+			err := RunReleases(t.Context(), releaseConfig)
 
 			isModuleSkipped := len(tc.skipModules) == 1
 			if tc.hasError {
@@ -97,3 +99,24 @@ versions:
 		})
 	}
 }
+
+func TestRunReleasesInterrupted(t *testing.T) {
+	commitMessage := "chore(release): unittest\n\nRelease-Plan: ch.open.kaeter:unit-test:0.1.0\n"
+	versionsYAML := mocks.GetEmptyVersionsYaml(t, "ch.open.kaeter:unit-test") + "\n  0.1.0: 1970-01-01T00:00:00Z|eeeeee"
+	testFolder, _ := mocks.CreateKaeterRepo(t, &mocks.KaeterModuleConfig{
+		OverrideCommitMessage: commitMessage,
+		Makefile:              mocks.TouchMakefileContent,
+		VersionsYAML:          versionsYAML,
+	})
+	ctx, cancel := context.WithCancelCause(t.Context())
+	cancel(&process.InterruptedError{Signal: os.Interrupt})
+
+	err := RunReleases(ctx, &ReleaseConfig{
+		RepositoryRoot:  testFolder,
+		RepositoryTrunk: "origin/main",
+		SkipCheckout:    true,
+	})
+
+	assert.ErrorContains(t, err, "release interrupted: interrupted by interrupt")
+	assert.NoFileExists(t, filepath.Join(testFolder, "build"))
+}
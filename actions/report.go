@@ -0,0 +1,227 @@
+package actions
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// Possible outcomes of a release target in the report
+const (
+	TargetStatusReleased = "released"
+	TargetStatusTested   = "tested" // dry-run releases stop after the test target
+	TargetStatusFailed   = "failed"
+	TargetStatusSkipped  = "skipped"
+)
+
+// ReleaseReport is a machine readable summary of a release plan run.
+type ReleaseReport struct {
+	ReleaseCommit   string          `json:"releaseCommit"`
+	DryRun          bool            `json:"dryRun"`
+	StartedAt       time.Time       `json:"startedAt"`
+	DurationSeconds float64         `json:"durationSeconds"`
+	Targets         []*TargetReport `json:"targets"`
+	mutex           sync.Mutex      // targets can be released concurrently
+}
+
+// TargetReport summarizes the release of a single target of the plan.
+type TargetReport struct {
+	ModuleID        string       `json:"moduleId"`
+	Version         string       `json:"version"`
+	Status          string       `json:"status"`
+	DurationSeconds float64      `json:"durationSeconds"`
+	Error           string       `json:"error,omitempty"`
+	SkipReason      string       `json:"skipReason,omitempty"`
+	Steps           []StepResult `json:"steps,omitempty"`
+	startedAt       time.Time
+}
+
+// StepResult holds the outcome of a single make target of a module release.
+type StepResult struct {
+	Name            string  `json:"name"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	ExitCode        int     `json:"exitCode"`
+	Error           string  `json:"error,omitempty"`
+}
+
+func newReleaseReport(releaseCommit string, dryRun bool) *ReleaseReport {
+	return &ReleaseReport{
+		ReleaseCommit: releaseCommit,
+		DryRun:        dryRun,
+		StartedAt:     time.Now().UTC(),
+	}
+}
+
+func newStepResult(name string, duration time.Duration, err error) StepResult {
+	step := StepResult{
+		Name:            name,
+		DurationSeconds: duration.Seconds(),
+	}
+	if err != nil {
+		step.Error = err.Error()
+		step.ExitCode = -1
+		if exitErr, ok := errors.AsType[*exec.ExitError](err); ok {
+			step.ExitCode = exitErr.ExitCode()
+		}
+	}
+	return step
+}
+
+func (report *ReleaseReport) skipTarget(target ReleaseTarget, reason string) {
+	report.mutex.Lock()
+	defer report.mutex.Unlock()
+	report.Targets = append(report.Targets, &TargetReport{
+		ModuleID:   target.ModuleID,
+		Version:    target.Version,
+		Status:     TargetStatusSkipped,
+		SkipReason: reason,
+	})
+}
+
+func (report *ReleaseReport) startTarget(target ReleaseTarget) *TargetReport {
+	report.mutex.Lock()
+	defer report.mutex.Unlock()
+	targetReport := &TargetReport{
+		ModuleID:  target.ModuleID,
+		Version:   target.Version,
+		startedAt: time.Now(),
+	}
+	report.Targets = append(report.Targets, targetReport)
+	return targetReport
+}
+
+func (targetReport *TargetReport) finish(err error, dryRun bool) {
+	targetReport.DurationSeconds = time.Since(targetReport.startedAt).Seconds()
+	switch {
+	case err != nil:
+		targetReport.Status = TargetStatusFailed
+		targetReport.Error = err.Error()
+	case dryRun:
+		targetReport.Status = TargetStatusTested
+	default:
+		targetReport.Status = TargetStatusReleased
+	}
+}
+
+func (report *ReleaseReport) finish() {
+	report.DurationSeconds = time.Since(report.StartedAt).Seconds()
+}
+
+// WriteJSON saves the report as JSON to the given path.
+func (report *ReleaseReport) WriteJSON(reportPath string) error {
+	bytes, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		return fmt.Errorf("unable to serialize release report: %w", err)
+	}
+	return os.WriteFile(reportPath, bytes, 0600)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     float64          `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      float64         `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit saves the report as JUnit XML to the given path, each release target is a
+// test suite and each of its make targets a test case.
+func (report *ReleaseReport) WriteJUnit(reportPath string) error {
+	suites := junitTestSuites{Name: "kaeter release", Time: report.DurationSeconds}
+	for _, target := range report.Targets {
+		suite := target.toJUnitSuite()
+		suites.Suites = append(suites.Suites, suite)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+	}
+
+	bytes, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to serialize release report to junit: %w", err)
+	}
+	return os.WriteFile(reportPath, append([]byte(xml.Header), bytes...), 0600)
+}
+
+func (targetReport *TargetReport) toJUnitSuite() junitTestSuite {
+	className := targetReport.ModuleID + ":" + targetReport.Version
+	suite := junitTestSuite{Name: className, Time: targetReport.DurationSeconds}
+
+	if targetReport.Status == TargetStatusSkipped {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      "release",
+			ClassName: className,
+			Skipped:   &junitSkipped{Message: targetReport.SkipReason},
+		})
+		suite.Tests, suite.Skipped = 1, 1
+		return suite
+	}
+
+	stepFailed := false
+	for _, step := range targetReport.Steps {
+		testCase := junitTestCase{Name: step.Name, ClassName: className, Time: step.DurationSeconds}
+		if step.Error != "" {
+			stepFailed = true
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("make target %s failed with exit code %d", step.Name, step.ExitCode),
+				Type:    "MakeTargetFailure",
+				Content: step.Error,
+			}
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	// Failures outside of make targets (i.e. checkout, missing module) get their own test case
+	if targetReport.Status == TargetStatusFailed && !stepFailed {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      "release",
+			ClassName: className,
+			Failure: &junitFailure{
+				Message: "release failed",
+				Type:    "ReleaseFailure",
+				Content: targetReport.Error,
+			},
+		})
+	}
+
+	suite.Tests = len(suite.TestCases)
+	for _, testCase := range suite.TestCases {
+		if testCase.Failure != nil {
+			suite.Failures++
+		}
+	}
+	return suite
+}
@@ -0,0 +1,134 @@
+package actions
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-ch/kaeter/mocks"
+)
+
+func TestNewStepResult(t *testing.T) {
+	exitErr := exec.Command("sh", "-c", "exit 3").Run()
+
+	var tests = []struct {
+		name             string
+		err              error
+		expectedExitCode int
+	}{
+		{name: "success", err: nil, expectedExitCode: 0},
+		{name: "exit code from failed command", err: exitErr, expectedExitCode: 3},
+		{name: "other errors", err: errors.New("make not found"), expectedExitCode: -1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			step := newStepResult("build", 2*time.Second, tc.err)
+
+			assert.Equal(t, "build", step.Name)
+			assert.InDelta(t, 2.0, step.DurationSeconds, 0.001)
+			assert.Equal(t, tc.expectedExitCode, step.ExitCode)
+			assert.Equal(t, tc.err != nil, step.Error != "")
+		})
+	}
+}
+
+func TestTargetReportToJUnitSuite(t *testing.T) {
+	var tests = []struct {
+		name             string
+		targetReport     *TargetReport
+		expectedTests    int
+		expectedFailures int
+		expectedSkipped  int
+	}{
+		{
+			name: "released target",
+			targetReport: &TargetReport{
+				Status: TargetStatusReleased,
+				Steps:  []StepResult{{Name: "build"}, {Name: "test"}, {Name: "release"}},
+			},
+			expectedTests: 3,
+		},
+		{
+			name: "failed step",
+			targetReport: &TargetReport{
+				Status: TargetStatusFailed,
+				Steps:  []StepResult{{Name: "build"}, {Name: "test", ExitCode: 2, Error: "failed"}},
+			},
+			expectedTests:    2,
+			expectedFailures: 1,
+		},
+		{
+			name:             "failure outside of steps",
+			targetReport:     &TargetReport{Status: TargetStatusFailed, Error: "module not found"},
+			expectedTests:    1,
+			expectedFailures: 1,
+		},
+		{
+			name:            "skipped target",
+			targetReport:    &TargetReport{Status: TargetStatusSkipped, SkipReason: "skipped by configuration"},
+			expectedTests:   1,
+			expectedSkipped: 1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			suite := tc.targetReport.toJUnitSuite()
+
+			assert.Equal(t, tc.expectedTests, suite.Tests)
+			assert.Equal(t, tc.expectedFailures, suite.Failures)
+			assert.Equal(t, tc.expectedSkipped, suite.Skipped)
+		})
+	}
+}
+
+func TestRunReleasesContinueOnError(t *testing.T) {
+	commitMessage := `chore(release): 2 modules
+
+Release-Plan: ch.open.kaeter:module-a:0.1.0
+Release-Plan: ch.open.kaeter:module-b:0.1.0
+`
+	versionsA := mocks.GetEmptyVersionsYaml(t, "ch.open.kaeter:module-a") + "\n  0.1.0: 1970-01-01T00:00:00Z|eeeeee"
+	versionsB := mocks.GetEmptyVersionsYaml(t, "ch.open.kaeter:module-b") + "\n  0.1.0: 1970-01-01T00:00:00Z|eeeeee"
+	testFolder, _ := mocks.CreateMockRepo(t)
+	_, _ = mocks.CreateKaeterModule(t, testFolder, &mocks.KaeterModuleConfig{
+		Path:         "module-a",
+		Makefile:     failingMakefileContent,
+		VersionsYAML: versionsA,
+	})
+	moduleB, _ := mocks.CreateKaeterModule(t, testFolder, &mocks.KaeterModuleConfig{
+		Path:                  "module-b",
+		Makefile:              mocks.TouchMakefileContent,
+		VersionsYAML:          versionsB,
+		OverrideCommitMessage: commitMessage,
+	})
+	reportFolder := mocks.CreateTmpFolder(t)
+	releaseConfig := &ReleaseConfig{
+		RepositoryRoot:  testFolder,
+		RepositoryTrunk: "origin/main",
+		SkipCheckout:    true,
+		ContinueOnError: true,
+		ReportJSONPath:  filepath.Join(reportFolder, "report.json"),
+		ReportJUnitPath: filepath.Join(reportFolder, "report.xml"),
+	}
+
+	err := RunReleases(t.Context(), releaseConfig)
+
+	assert.ErrorContains(t, err, "ch.open.kaeter:module-a:0.1.0")
+	assert.FileExists(t, filepath.Join(moduleB, "release"))
+	assert.FileExists(t, releaseConfig.ReportJUnitPath)
+	rawReport, err := os.ReadFile(releaseConfig.ReportJSONPath)
+	assert.NoError(t, err)
+	report := &ReleaseReport{}
+	assert.NoError(t, json.Unmarshal(rawReport, report))
+	assert.Len(t, report.Targets, 2)
+	assert.Equal(t, TargetStatusFailed, report.Targets[0].Status)
+	assert.Equal(t, 2, report.Targets[0].Steps[0].ExitCode)
+	assert.Equal(t, TargetStatusReleased, report.Targets[1].Status)
+	assert.Len(t, report.Targets[1].Steps, 3)
+}
@@ -0,0 +1,178 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/open-ch/kaeter/inventory"
+)
+
+// releaseJob is a target of the release plan along with the
+// jobs (indexes in the schedule) it depends on.
+type releaseJob struct {
+	target    ReleaseTarget
+	dependsOn []int
+}
+
+type releaseJobState int
+
+const (
+	releaseJobPending releaseJobState = iota
+	releaseJobRunning
+	releaseJobSucceeded
+	releaseJobFailed
+	releaseJobBlocked // a dependency failed, the job will not run
+)
+
+// scheduleReleaseJobs orders the release targets so that modules are released after the
+// modules of the plan they depend on. The plan order is kept wherever dependencies allow it.
+// A target depends on another if one of its dependency paths is part of the other module.
+func scheduleReleaseJobs(moduleInventory *inventory.Inventory, targets []ReleaseTarget) ([]releaseJob, error) {
+	planIndex := make(map[string]int, len(targets))
+	for i, target := range targets {
+		planIndex[target.ModuleID] = i
+	}
+	dependencies := make([][]int, len(targets))
+	for i, target := range targets {
+		module, found := moduleInventory.Lookup[target.ModuleID]
+		if !found {
+			// Unknown modules have no dependencies, their release will fail when attempted
+			continue
+		}
+		for _, dependencyPath := range module.Dependencies {
+			dependencyModule := moduleInventory.FindModuleContainingPath(dependencyPath)
+			if dependencyModule == nil || dependencyModule.ModuleID == target.ModuleID {
+				continue
+			}
+			if j, inPlan := planIndex[dependencyModule.ModuleID]; inPlan {
+				dependencies[i] = append(dependencies[i], j)
+			}
+		}
+	}
+
+	// Topological sort always picking the first target of the plan which is ready
+	order := make([]int, 0, len(targets))
+	scheduledAt := make([]int, len(targets))
+	scheduled := make([]bool, len(targets))
+	for len(order) < len(targets) {
+		next := -1
+		for i := range targets {
+			if !scheduled[i] && allScheduled(dependencies[i], scheduled) {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return nil, fmt.Errorf("dependency cycle between release targets: %s", unscheduledTargets(targets, scheduled))
+		}
+		scheduled[next] = true
+		scheduledAt[next] = len(order)
+		order = append(order, next)
+	}
+
+	jobs := make([]releaseJob, len(order))
+	for position, i := range order {
+		jobs[position].target = targets[i]
+		for _, j := range dependencies[i] {
+			jobs[position].dependsOn = append(jobs[position].dependsOn, scheduledAt[j])
+		}
+	}
+	return jobs, nil
+}
+
+func allScheduled(indexes []int, scheduled []bool) bool {
+	for _, i := range indexes {
+		if !scheduled[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func unscheduledTargets(targets []ReleaseTarget, scheduled []bool) string {
+	var remaining []string
+	for i, target := range targets {
+		if !scheduled[i] {
+			remaining = append(remaining, target.Marshal())
+		}
+	}
+	return strings.Join(remaining, ", ")
+}
+
+// runReleaseJobs runs at most parallel jobs at once, in schedule order, each job only starts once all
+// the jobs it depends on succeeded. Jobs depending on a failed job are passed to onBlocked instead.
+// Unless continueOnError is set no new job is started after a failure, running jobs are awaited.
+// No new job is started once the context is cancelled either.
+func runReleaseJobs(
+	ctx context.Context,
+	jobs []releaseJob,
+	parallel int,
+	continueOnError bool,
+	run func(job releaseJob) error,
+	onBlocked func(job releaseJob, failedDependency ReleaseTarget),
+) {
+	type jobResult struct {
+		index int
+		err   error
+	}
+	parallel = max(parallel, 1)
+	states := make([]releaseJobState, len(jobs))
+	results := make(chan jobResult)
+	running := 0
+	stopped := false
+
+	for {
+		// Jobs are sorted so that dependencies are always before the jobs depending on them,
+		// a single pass is enough to start or block every job which can be.
+		for i := range jobs {
+			if stopped || ctx.Err() != nil || running >= parallel {
+				break
+			}
+			if states[i] != releaseJobPending {
+				continue
+			}
+			ready, failedDependency := dependenciesState(jobs[i], states)
+			if failedDependency >= 0 {
+				states[i] = releaseJobBlocked
+				onBlocked(jobs[i], jobs[failedDependency].target)
+				continue
+			}
+			if !ready {
+				continue
+			}
+			states[i] = releaseJobRunning
+			running++
+			go func() {
+				results <- jobResult{index: i, err: run(jobs[i])}
+			}()
+		}
+		if running == 0 {
+			return
+		}
+
+		result := <-results
+		running--
+		states[result.index] = releaseJobSucceeded
+		if result.err != nil {
+			states[result.index] = releaseJobFailed
+			stopped = !continueOnError
+		}
+	}
+}
+
+// dependenciesState returns whether all dependencies of the job succeeded
+// or the index of the first which failed or is blocked, -1 otherwise.
+func dependenciesState(job releaseJob, states []releaseJobState) (ready bool, failedDependency int) {
+	ready = true
+	for _, dependency := range job.dependsOn {
+		switch states[dependency] {
+		case releaseJobFailed, releaseJobBlocked:
+			return false, dependency
+		case releaseJobSucceeded:
+		default:
+			ready = false
+		}
+	}
+	return ready, -1
+}
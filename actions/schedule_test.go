@@ -0,0 +1,216 @@
+package actions
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-ch/kaeter/inventory"
+	"github.com/open-ch/kaeter/mocks"
+	"github.com/open-ch/kaeter/modules"
+)
+
+func mockScheduleInventory(t *testing.T, kaeterModules ...modules.KaeterModule) *inventory.Inventory {
+	t.Helper()
+	inv := &inventory.Inventory{
+		Lookup:          make(map[string]modules.KaeterModule),
+		ModuleInventory: inventory.ModuleInventory{Modules: kaeterModules},
+	}
+	for _, module := range kaeterModules {
+		inv.Lookup[module.ModuleID] = module
+	}
+	return inv
+}
+
+func TestScheduleReleaseJobs(t *testing.T) {
+	var tests = []struct {
+		name          string
+		modules       []modules.KaeterModule
+		plan          []string
+		expectedOrder []string
+		hasError      bool
+	}{
+		{
+			name: "independent modules keep plan order",
+			modules: []modules.KaeterModule{
+				{ModuleID: "a", ModulePath: "a"},
+				{ModuleID: "b", ModulePath: "b"},
+			},
+			plan:          []string{"b", "a"},
+			expectedOrder: []string{"b", "a"},
+		},
+		{
+			name: "dependencies are released first",
+			modules: []modules.KaeterModule{
+				{ModuleID: "app", ModulePath: "app", Dependencies: []string{"lib/src"}},
+				{ModuleID: "lib", ModulePath: "lib"},
+				{ModuleID: "tool", ModulePath: "tool"},
+			},
+			plan:          []string{"app", "tool", "lib"},
+			expectedOrder: []string{"tool", "lib", "app"},
+		},
+		{
+			name: "dependencies outside of the plan are ignored",
+			modules: []modules.KaeterModule{
+				{ModuleID: "app", ModulePath: "app", Dependencies: []string{"lib", "go.mod"}},
+				{ModuleID: "lib", ModulePath: "lib"},
+			},
+			plan:          []string{"app"},
+			expectedOrder: []string{"app"},
+		},
+		{
+			name: "unknown modules are scheduled",
+			modules: []modules.KaeterModule{
+				{ModuleID: "a", ModulePath: "a"},
+			},
+			plan:          []string{"unknown", "a"},
+			expectedOrder: []string{"unknown", "a"},
+		},
+		{
+			name: "fails on dependency cycle",
+			modules: []modules.KaeterModule{
+				{ModuleID: "a", ModulePath: "a", Dependencies: []string{"b"}},
+				{ModuleID: "b", ModulePath: "b", Dependencies: []string{"a"}},
+			},
+			plan:     []string{"a", "b"},
+			hasError: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			targets := make([]ReleaseTarget, len(tc.plan))
+			for i, moduleID := range tc.plan {
+				targets[i] = ReleaseTarget{ModuleID: moduleID, Version: "1.0.0"}
+			}
+
+			jobs, err := scheduleReleaseJobs(mockScheduleInventory(t, tc.modules...), targets)
+
+			if tc.hasError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			order := make([]string, len(jobs))
+			for i, job := range jobs {
+				order[i] = job.target.ModuleID
+				for _, dependency := range job.dependsOn {
+					assert.Less(t, dependency, i, "dependencies must be scheduled first")
+				}
+			}
+			assert.Equal(t, tc.expectedOrder, order)
+		})
+	}
+}
+
+func TestRunReleaseJobs(t *testing.T) {
+	// c depends on a, d depends on c
+	jobs := []releaseJob{
+		{target: ReleaseTarget{ModuleID: "a"}},
+		{target: ReleaseTarget{ModuleID: "b"}},
+		{target: ReleaseTarget{ModuleID: "c"}, dependsOn: []int{0}},
+		{target: ReleaseTarget{ModuleID: "d"}, dependsOn: []int{2}},
+	}
+	var tests = []struct {
+		name            string
+		parallel        int
+		continueOnError bool
+		failing         string
+		expectedRun     []string
+		expectedBlocked []string
+	}{
+		{
+			name:        "runs all jobs sequentially",
+			parallel:    1,
+			expectedRun: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:        "runs all jobs in parallel",
+			parallel:    4,
+			expectedRun: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:        "stops after first failure",
+			parallel:    1,
+			failing:     "a",
+			expectedRun: []string{"a"},
+		},
+		{
+			name:            "blocks dependents of failed jobs",
+			parallel:        2,
+			continueOnError: true,
+			failing:         "a",
+			expectedRun:     []string{"a", "b"},
+			expectedBlocked: []string{"c", "d"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var mutex sync.Mutex
+			var run, blocked []string
+
+			runReleaseJobs(t.Context(), jobs, tc.parallel, tc.continueOnError,
+				func(job releaseJob) error {
+					mutex.Lock()
+					defer mutex.Unlock()
+					run = append(run, job.target.ModuleID)
+					if job.target.ModuleID == tc.failing {
+						return errors.New("release failed")
+					}
+					return nil
+				},
+				func(job releaseJob, _ ReleaseTarget) {
+					blocked = append(blocked, job.target.ModuleID)
+				},
+			)
+
+			assert.ElementsMatch(t, tc.expectedRun, run)
+			assert.Equal(t, tc.expectedBlocked, blocked)
+		})
+	}
+}
+
+func TestRunReleasesParallel(t *testing.T) {
+	commitMessage := `chore(release): 2 modules
+
+Release-Plan: ch.open.kaeter:module-a:0.1.0
+Release-Plan: ch.open.kaeter:module-b:0.1.0
+`
+	versionsA := mocks.GetEmptyVersionsYaml(t, "ch.open.kaeter:module-a")
+	versionsB := mocks.GetEmptyVersionsYaml(t, "ch.open.kaeter:module-b")
+	testFolder, _ := mocks.CreateMockRepo(t)
+	moduleA, _ := mocks.CreateKaeterModule(t, testFolder, &mocks.KaeterModuleConfig{
+		Path:         "module-a",
+		Makefile:     ".PHONY: build test release\nbuild:\n\ttouch build\ntest:\nrelease:\n\ttouch $(CURDIR)/../../released-a",
+		VersionsYAML: versionsA,
+	})
+	_, commitHash := mocks.CreateKaeterModule(t, testFolder, &mocks.KaeterModuleConfig{
+		Path:         "module-b",
+		Makefile:     ".PHONY: build test release\nbuild:\n\ttouch build\ntest:\nrelease:\n\ttouch $(CURDIR)/../../released-b",
+		VersionsYAML: versionsB,
+	})
+	versionSuffix := "\n  0.1.0: 1970-01-01T00:00:00Z|" + commitHash
+	mocks.CreateMockFile(t, moduleA, "versions.yaml", versionsA+versionSuffix)
+	mocks.CreateMockFile(t, filepath.Join(testFolder, "module-b"), "versions.yaml", versionsB+versionSuffix)
+	mocks.CommitFileAndGetHash(t, testFolder, "release", "", commitMessage)
+	worktreesFolder := mocks.CreateTmpFolder(t)
+	t.Setenv("TMPDIR", worktreesFolder)
+
+	err := RunReleases(t.Context(), &ReleaseConfig{
+		RepositoryRoot:  testFolder,
+		RepositoryTrunk: "origin/main",
+		Parallel:        2,
+	})
+
+	assert.NoError(t, err)
+	// Targets ran in worktrees created in the temp folder, which are cleaned up, not in the repository
+	assert.NoFileExists(t, filepath.Join(moduleA, "build"))
+	assert.FileExists(t, filepath.Join(worktreesFolder, "released-a"))
+	assert.FileExists(t, filepath.Join(worktreesFolder, "released-b"))
+	worktrees, err := os.ReadDir(worktreesFolder)
+	assert.NoError(t, err)
+	assert.Len(t, worktrees, 2, "only the released files should be left")
+}
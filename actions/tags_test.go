@@ -76,7 +76,7 @@ func TestAutoReleaseWithTags(t *testing.T) {
 				SkipLint:       tc.skipLint,
 			}
 
-			err := AutoRelease(config)
+			err := AutoRelease(t.Context(), config)
 
 			if tc.expectError {
 				assert.Error(t, err)
@@ -1,7 +1,11 @@
 package ci
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/open-ch/kaeter/actions"
+	"github.com/open-ch/kaeter/git"
 	"github.com/open-ch/kaeter/log"
 	"github.com/open-ch/kaeter/modules"
 )
@@ -10,6 +14,7 @@ import (
 // configure a specific release
 type ReleaseConfig struct {
 	DryRun     bool
+	LogDir     string // Optional folder to save the output of each make target to
 	ModulePath string
 }
 
@@ -19,7 +24,7 @@ type ReleaseConfig struct {
 // - test
 // - release (or snapshot if requested)
 // using the latest version number by default.
-func (rc *ReleaseConfig) ReleaseSingleModule() error {
+func (rc *ReleaseConfig) ReleaseSingleModule(ctx context.Context) error {
 	log.Info("Loading module for release", "modulePath", rc.ModulePath)
 
 	absVersionsPath, err := modules.GetVersionsFilePath(rc.ModulePath)
@@ -34,14 +39,20 @@ func (rc *ReleaseConfig) ReleaseSingleModule() error {
 
 	latestVersion := versions.ReleasedVersions[len(versions.ReleasedVersions)-1].Number.String()
 	log.Debug("latest version", "version", latestVersion)
+	repoRoot, err := git.ShowTopLevel(rc.ModulePath)
+	if err != nil {
+		return fmt.Errorf("unable to find repository of module %s: %w", rc.ModulePath, err)
+	}
 
-	err = actions.RunModuleRelease(&actions.ModuleRelease{
+	err = actions.RunModuleRelease(ctx, &actions.ModuleRelease{
 		DryRun:       rc.DryRun,
+		LogDir:       rc.LogDir,
 		SkipCheckout: true,
 		ReleaseTarget: actions.ReleaseTarget{
 			ModuleID: versions.ID,
 			Version:  latestVersion,
 		},
+		RepositoryRoot:   repoRoot,
 		VersionsYAMLPath: absVersionsPath,
 		VersionsData:     versions,
 	})
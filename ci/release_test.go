@@ -1,6 +1,7 @@
 package ci
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -44,7 +45,7 @@ func TestReleaseSingleModule(t *testing.T) {
 				DryRun:     tc.dryrun,
 				ModulePath: testFolder,
 			}
-			err := rc.ReleaseSingleModule()
+			err := rc.ReleaseSingleModule(t.Context())
 
 			if tc.expectedError {
 				assert.Error(t, err)
@@ -61,3 +62,26 @@ func TestReleaseSingleModule(t *testing.T) {
 		})
 	}
 }
+
+func TestReleaseSingleModuleFromSymlinkedCheckout(t *testing.T) {
+	testFolder, _ := mocks.CreateMockRepo(t)
+	mocks.CreateKaeterModule(t, testFolder, &mocks.KaeterModuleConfig{
+		Path:         "module1",
+		Makefile:     ".PHONY: build test release\nbuild:\n\tprintf '%s' \"$$KAETER_MODULE_PATH\" > module-path\ntest:\nrelease:\n",
+		VersionsYAML: mocks.EmptyVersionsYAML,
+	})
+	checkoutLink := filepath.Join(mocks.CreateTmpFolder(t), "checkout")
+	err := os.Symlink(testFolder, checkoutLink)
+	assert.NoError(t, err)
+
+	rc := &ReleaseConfig{
+		DryRun:     true,
+		ModulePath: filepath.Join(checkoutLink, "module1"),
+	}
+	err = rc.ReleaseSingleModule(t.Context())
+
+	assert.NoError(t, err)
+	modulePath, err := os.ReadFile(filepath.Join(testFolder, "module1", "module-path"))
+	assert.NoError(t, err)
+	assert.Equal(t, "module1", string(modulePath))
+}
@@ -14,6 +14,7 @@ import (
 
 func getAutoreleaseCommand() *cobra.Command {
 	skipLint := false
+	scaffoldChangelog := false
 	autoreleaseCmd := &cobra.Command{
 		Use:     "autorelease --path <PATH> --version <VERSION>",
 		Aliases: []string{"ar"},
@@ -24,6 +25,7 @@ local counterpart of kaeter ci autorelease plan to be used in a pipeline
 to release on merge.
 
 - Can be called multiple times for multiple modules to be released
+- With --changelog an entry for the version is added to the changelog
 `,
 		PreRunE: validateAllPathFlags,
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -57,15 +59,16 @@ to release on merge.
 			}
 
 			config := &actions.AutoReleaseConfig{
-				ModulePath:     modulePath,
-				RepositoryRef:  viper.GetString("git.main.branch"),
-				RepositoryRoot: viper.GetString("reporoot"),
-				ReleaseVersion: version,
-				Tags:           tagsPtr,
-				SkipLint:       skipLint,
+				ModulePath:        modulePath,
+				RepositoryRef:     viper.GetString("git.main.branch"),
+				RepositoryRoot:    viper.GetString("reporoot"),
+				ReleaseVersion:    version,
+				Tags:              tagsPtr,
+				ScaffoldChangelog: scaffoldChangelog,
+				SkipLint:          skipLint,
 			}
 
-			return actions.AutoRelease(config)
+			return actions.AutoRelease(cmd.Context(), config)
 		},
 	}
 
@@ -73,6 +76,8 @@ to release on merge.
 		"Version number to use when the release will be triggered on CI.")
 	autoreleaseCmd.Flags().StringSlice("tags", nil,
 		"Comma-separated list of custom tags for this release (e.g., production,stable,lts).")
+	autoreleaseCmd.Flags().BoolVar(&scaffoldChangelog, "changelog", false,
+		"Add an entry for the version to the changelog (CHANGELOG.md, CHANGES, debian/changelog, ...), pre-filled with the commit subjects since the previous release.")
 	autoreleaseCmd.Flags().BoolVar(&skipLint, "skip-lint", false,
 		"Skips validation of the release, use at your own risk for broken builds.")
 
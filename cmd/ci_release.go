@@ -11,11 +11,12 @@ import (
 
 func getCIReleaseCommand() *cobra.Command {
 	var dryrun bool
+	var logDir string
 
 	cmd := &cobra.Command{
 		Use:   "release",
 		Short: "Performs a ci release of a single module",
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(cmd *cobra.Command, _ []string) error {
 			modulePaths := viper.GetStringSlice("path")
 			if len(modulePaths) != 1 {
 				return fmt.Errorf("only a single module can be released at a time, got: %d", len(modulePaths))
@@ -23,14 +24,16 @@ func getCIReleaseCommand() *cobra.Command {
 
 			rc := &ci.ReleaseConfig{
 				DryRun:     dryrun,
+				LogDir:     logDir,
 				ModulePath: modulePaths[0],
 			}
-			return rc.ReleaseSingleModule()
+			return rc.ReleaseSingleModule(cmd.Context())
 		},
 	}
 
 	flags := cmd.Flags()
 	flags.BoolVar(&dryrun, "dry-run", false, "Build and test but don't push the release")
+	flags.StringVar(&logDir, "log-dir", "", "If provided the output of each make target is saved to a file in that folder")
 	// add --version flag or --snapshot flag to use this command for snapshots as well.
 
 	return cmd
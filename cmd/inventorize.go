@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -10,30 +11,72 @@ import (
 )
 
 func getInventorizeCommand() *cobra.Command {
+	var filterExpression string
+	var outputFormat string
+
 	createInventoryCmd := &cobra.Command{
-		Use:     "inventorize",
-		Short:   "Create an inventory of kaeter modules in given path and print to STDOUT",
-		Long:    `This command extracts all kaeter modules and returns them as list to STDOUT.`,
+		Use:   "inventorize",
+		Short: "Create an inventory of kaeter modules in given path and print to STDOUT",
+		Long: `This command extracts all kaeter modules and returns them as list to STDOUT.
+
+The modules can be narrowed down with a filter expression made of clauses joined by &&:
+  kaeter inventorize --filter 'annotation[team]=payments && type=Makefile'
+Supported fields: id, path, type, tag, dependency, autorelease and annotation[key].
+Supported operators: = (equal), != (not equal), ^= (prefix) or no operator to match
+modules where the field is set (i.e. 'autorelease' for pending autoreleases).
+
+With --format backstage the modules are exported as backstage Component entities,
+see the backstage section of the configuration in the README for mapping annotations.`,
 		PreRunE: validateAllPathFlags,
 		RunE: func(_ *cobra.Command, _ []string) error {
 			// this command does not need "path" information, we "just" need repoRoot.
 			// however, current implementation of evaluating repoRoot requires the path flag.
 			// that's why we call validateAllPathFlags in PreRunE.
 			repositoryPath := viper.GetString("repoRoot")
+			filter, err := inventory.ParseFilter(filterExpression)
+			if err != nil {
+				return fmt.Errorf("invalid filter: %w", err)
+			}
 			inv, err := inventory.InventorizeRepo(repositoryPath)
 			if err != nil {
 				return fmt.Errorf("failed to create module inventory: %w", err)
 			}
+			inv = inv.Filter(filter)
 
-			var stringJSON string
-			stringJSON, err = inv.ToJSON()
+			output, err := formatInventory(inv, outputFormat)
 			if err != nil {
-				return fmt.Errorf("could not marshal kaeter modules to JSON: %w", err)
+				return err
 			}
-			fmt.Println(stringJSON)
+			fmt.Println(output)
 			return nil
 		},
 	}
+	createInventoryCmd.Flags().StringVar(&filterExpression, "filter", "", "only include modules matching the filter expression")
+	createInventoryCmd.Flags().StringVar(&outputFormat, "format", "json", "output format: json, ids, paths or backstage (catalog-info.yaml entities)")
 
 	return createInventoryCmd
 }
+
+func formatInventory(inv *inventory.Inventory, format string) (string, error) {
+	switch format {
+	case "json":
+		stringJSON, err := inv.ToJSON()
+		if err != nil {
+			return "", fmt.Errorf("could not marshal kaeter modules to JSON: %w", err)
+		}
+		return stringJSON, nil
+	case "ids":
+		return strings.Join(inv.ModuleIDs(), "\n"), nil
+	case "paths":
+		return strings.Join(inv.ModulePaths(), "\n"), nil
+	case "backstage":
+		config := &inventory.BackstageConfig{}
+		err := viper.UnmarshalKey("backstage", config)
+		if err != nil {
+			return "", fmt.Errorf("invalid backstage configuration: %w", err)
+		}
+		return inv.ToBackstage(config)
+	default:
+		return "", fmt.Errorf("unsupported output format: %s (supported: json, ids, paths, backstage)", format)
+	}
+}
@@ -1,44 +1,95 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/open-ch/kaeter/change"
 	"github.com/open-ch/kaeter/lint"
 )
 
 func getLintCommand() *cobra.Command {
 	var strict bool
+	var outputFormat string
+	var changesetPath string
+	var fix bool
 	command := &cobra.Command{
 		Use:   "lint",
 		Short: "Basic quality checks for the detected modules.",
 		Long: `Then detects Kaeter modules starting from the given path,
 for every kaeter-managed module (which has a versions.yaml file) the following
-are checked:
-- the existence of README.md
-- the existence of a changelog (defaults to CHANGELOG.md)
-- the changelog is up-to-date with versions.yaml (for releases)
-- the dependencies listed in versions.yaml are existing paths
-- the detected kaeter Makefile contains valid required targets
+rules are checked:
+- dependencies-exist: the dependencies listed in versions.yaml are existing paths
+- versions-sorted: the versions of versions.yaml are listed in release order (warning by default)
+- readme-exists: the existence of README.md
+- changelog-exists: the existence of a changelog (defaults to CHANGELOG.md)
+- changelog-in-sync: the changelog is up-to-date with versions.yaml (for releases)
+- changelog-dates: the changelog dates match the release dates of versions.yaml, entries
+  are in descending version order without duplicates (except CHANGES and .spec, warning by default)
+- makefile-targets: the detected kaeter Makefile contains a target for each release step
+  (build, test and release unless configured otherwise)
+- dangling-autorelease: the module has no pending/dangling autorelease (strict only)
 
-Strict only checks:
-- the module has no pending/dangling autorelease
+The severity of each rule (error, warning or off) can be set with lint.rules in the
+config and per module with open.ch/kaeter-lint/<rule> annotations, only errors fail lint.
 
-on error it will include details about all issues detected in all the scanned modules.`,
+on error it will include details about all issues detected in all the scanned modules.
+
+With --format the findings (rule, module, file, line and message) are printed as json,
+sarif (for code scanning), github (workflow commands annotating pull requests) or
+gitlab (Code Quality report), lint still fails if any of them is an error.
+
+All modules of the repository are linted unless --path or --changeset is given,
+then only the modules in the given paths, or the modules changed according to the
+changeset.json of kaeter ci detect-changes, are linted along with the modules
+depending on them.
+
+With --fix the safe fixes are applied before linting and listed:
+- versions-sorted: the versions of versions.yaml are sorted by release date
+- readme-exists and changelog-exists: README.md and CHANGELOG.md are created from
+  the templates of kaeter init
+- changelog-in-sync: entry headers are added for the versions released after the
+  latest version in the changelog
+The issues which can't be fixed are reported as usual.`,
 		PreRunE: validateAllPathFlags,
-		RunE: func(_ *cobra.Command, _ []string) error {
-			// TODO allow using --path instead of repoRoot to lint subset
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !slices.Contains(lintOutputFormats, outputFormat) {
+				return unsupportedLintFormatError(outputFormat)
+			}
 			repositoryRoot := viper.GetString("repoRoot")
 			if strict {
 				log.Info("Linting in strict mode.")
 			}
-			err := lint.CheckModulesStartingFrom(lint.CheckConfig{
+			paths, err := getLintPaths(repositoryRoot, cmd.Flags().Changed("path"), changesetPath)
+			if err != nil {
+				return err
+			}
+			if paths != nil && len(paths) == 0 {
+				log.Info("No modules changed in the changeset, nothing to lint.")
+				return nil
+			}
+			config := lint.CheckConfig{
 				RepoRoot: repositoryRoot,
 				Strict:   strict,
-			})
+				Paths:    paths,
+			}
+			if fix {
+				err = fixLintIssues(config, outputFormat)
+				if err != nil {
+					return fmt.Errorf("lint failed: %w", err)
+				}
+			}
+			if outputFormat != lint.FormatText {
+				return printLintFindings(config, outputFormat)
+			}
+			err = lint.CheckModulesStartingFrom(config)
 			if err != nil {
 				return fmt.Errorf("lint failed: %w", err)
 			}
@@ -47,7 +98,116 @@ on error it will include details about all issues detected in all the scanned mo
 		},
 	}
 
-	command.Flags().BoolVar(&strict, "strict", false, "Enable additional strict checks when validating modules")
+	command.Flags().BoolVar(&strict, "strict", false, "Enable additional strict checks (dangling-autorelease) when validating modules")
+	command.Flags().StringVar(&outputFormat, "format", lint.FormatText, "output format: text, json, sarif, github or gitlab")
+	command.Flags().BoolVar(&fix, "fix", false, "Apply safe fixes (sort versions, create README.md/CHANGELOG.md, add missing changelog entries) before linting")
+	command.Flags().StringVar(&changesetPath, "changeset", "",
+		"The path to a changeset.json from kaeter ci detect-changes, only the changed modules and their dependents are linted")
 
 	return command
 }
+
+// lintOutputFormats are the values supported by --format
+var lintOutputFormats = []string{lint.FormatText, lint.FormatJSON, lint.FormatSARIF, lint.FormatGitHub, lint.FormatGitLab}
+
+func unsupportedLintFormatError(format string) error {
+	return fmt.Errorf("unsupported output format: %s (supported: %s)", format, strings.Join(lintOutputFormats, ", "))
+}
+
+// fixLintIssues applies the fixes and lists them, on stdout unless it is used for the findings of another format
+func fixLintIssues(config lint.CheckConfig, format string) error {
+	fixes, err := lint.FixModulesStartingFrom(config)
+	if err != nil {
+		return err
+	}
+	for _, fix := range fixes {
+		if format == lint.FormatText {
+			fmt.Printf("Fixed %s: %s (%s)\n", fix.File, fix.Description, fix.Rule)
+		} else {
+			log.Info("Fixed lint issue", "rule", fix.Rule, "moduleID", fix.ModuleID, "file", fix.File, "change", fix.Description)
+		}
+	}
+	if len(fixes) > 0 {
+		log.Info("Review the fixes before committing them.", "count", len(fixes))
+	}
+	return nil
+}
+
+// getLintPaths returns the paths relative to the repository to lint: the module paths of the changeset
+// (empty if no module changed), the --path flags if set or nil to lint the whole repository.
+func getLintPaths(repositoryRoot string, pathFlagSet bool, changesetPath string) ([]string, error) {
+	if changesetPath != "" {
+		if pathFlagSet {
+			return nil, errors.New("--changeset and --path can't be used together")
+		}
+		changeset, err := change.LoadChangeset(changesetPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not load changeset: %w", err)
+		}
+		paths := []string{}
+		for _, module := range changeset.Kaeter.Modules {
+			paths = append(paths, module.ModulePath)
+		}
+		slices.Sort(paths)
+		return paths, nil
+	}
+	if !pathFlagSet {
+		return nil, nil
+	}
+
+	// Module paths are relative to the repository with symlinks resolved
+	resolvedRoot, err := filepath.EvalSymlinks(repositoryRoot)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve repository root %s: %w", repositoryRoot, err)
+	}
+	var paths []string
+	for _, modulePath := range viper.GetStringSlice("path") {
+		resolvedPath, err := filepath.EvalSymlinks(modulePath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve path %s: %w", modulePath, err)
+		}
+		absPath, err := filepath.Abs(resolvedPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve path %s: %w", modulePath, err)
+		}
+		relativePath, err := filepath.Rel(resolvedRoot, absPath)
+		if err != nil || !filepath.IsLocal(relativePath) {
+			return nil, fmt.Errorf("path %s is not in the repository %s", modulePath, repositoryRoot)
+		}
+		paths = append(paths, relativePath)
+	}
+	return paths, nil
+}
+
+// printLintFindings prints the findings of all the modules in the given format and fails if any is an error
+func printLintFindings(config lint.CheckConfig, format string) error {
+	findings, err := lint.FindIssuesStartingFrom(config)
+	if err != nil {
+		return fmt.Errorf("lint failed: %w", err)
+	}
+
+	var output string
+	switch format {
+	case lint.FormatJSON:
+		output, err = lint.Findings(findings).ToJSON()
+	case lint.FormatSARIF:
+		output, err = lint.Findings(findings).ToSARIF()
+	case lint.FormatGitHub:
+		output = strings.TrimSuffix(lint.Findings(findings).ToGitHubAnnotations(), "\n")
+	case lint.FormatGitLab:
+		output, err = lint.Findings(findings).ToGitLabCodeQuality()
+	default:
+		return unsupportedLintFormatError(format)
+	}
+	if err != nil {
+		return err
+	}
+	if output != "" {
+		fmt.Println(output)
+	}
+
+	if errorCount := lint.Findings(findings).ErrorCount(); errorCount > 0 {
+		return fmt.Errorf("lint failed: %d error(s) detected", errorCount)
+	}
+	return nil
+}
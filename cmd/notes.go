@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/open-ch/kaeter/lint"
+	"github.com/open-ch/kaeter/modules"
+)
+
+func getNotesCommand() *cobra.Command {
+	var version string
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "notes",
+		Short: "Prints the release notes of a version of a module",
+		Long: `Prints the release notes of a version from the changelog of the module at --path
+(CHANGES, CHANGELOG.md, CHANGELOG.adoc, CHANGELOG.rst, debian/changelog or the %changelog
+section of a .spec file), defaults to the latest version of versions.yaml.
+
+The notes are printed as is in markdown or as json with the version and changelog file.`,
+		PreRunE: validateAllPathFlags,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			modulePaths := viper.GetStringSlice("path")
+			if len(modulePaths) != 1 {
+				return errors.New("notes expects a single module --path")
+			}
+			modulePath, err := filepath.Abs(modulePaths[0])
+			if err != nil {
+				return err
+			}
+			if version == "" {
+				versionsPath, err := modules.GetVersionsFilePath(modulePath)
+				if err != nil {
+					return err
+				}
+				versions, err := modules.ReadFromFile(versionsPath)
+				if err != nil {
+					return fmt.Errorf("unable to find latest version: %w", err)
+				}
+				version = versions.ReleasedVersions[len(versions.ReleasedVersions)-1].Number.String()
+			}
+
+			notes, err := lint.GetReleaseNotes(modulePath, version)
+			if err != nil {
+				return err
+			}
+			output, err := formatReleaseNotes(notes, outputFormat)
+			if err != nil {
+				return err
+			}
+			fmt.Println(output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&version, "version", "", "The version to print the notes of, defaults to the latest one")
+	cmd.Flags().StringVar(&outputFormat, "format", "md", "output format: md or json")
+
+	return cmd
+}
+
+func formatReleaseNotes(notes *lint.ReleaseNotes, format string) (string, error) {
+	switch format {
+	case "md":
+		return notes.Notes, nil
+	case "json":
+		notesJSON, err := json.MarshalIndent(notes, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("could not marshal release notes to JSON: %w", err)
+		}
+		return string(notesJSON), nil
+	default:
+		return "", fmt.Errorf("unsupported output format: %s (supported: md, json)", format)
+	}
+}
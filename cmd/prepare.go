@@ -12,6 +12,7 @@ func getPrepareCommand() *cobra.Command {
 	var major bool
 	var minor bool
 	var releaseFrom string
+	var scaffoldChangelog bool
 	var skipLint bool
 	var tags []string
 	var userProvidedVersion string
@@ -23,6 +24,7 @@ func getPrepareCommand() *cobra.Command {
 and the flags passed to it, this command will:
  - determine the next version to be released, using either SemVer of CalVer
  - update the versions.yaml file for the relevant project
+ - with --changelog add an entry for the version to the changelog listing the commits since the previous release
  - serialize the release plan to a commit`,
 		PreRunE: validateAllPathFlags,
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -45,6 +47,7 @@ and the flags passed to it, this command will:
 				ModulePaths:         viper.GetStringSlice("path"),
 				RepositoryRef:       viper.GetString("git.main.branch"),
 				RepositoryRoot:      viper.GetString("repoRoot"),
+				ScaffoldChangelog:   scaffoldChangelog,
 				Tags:                tagsPtr,
 				UserProvidedVersion: userProvidedVersion,
 				SkipLint:            skipLint,
@@ -54,7 +57,7 @@ and the flags passed to it, this command will:
 				prepareConfig.RepositoryRef = releaseFrom
 			}
 
-			return actions.PrepareRelease(prepareConfig)
+			return actions.PrepareRelease(cmd.Context(), prepareConfig)
 		},
 	}
 
@@ -71,6 +74,8 @@ and the flags passed to it, this command will:
 Default: git-main-branch from the config (can be a branch, a tag or a commit hash).`)
 	flags.StringSliceVar(&tags, "tags", nil,
 		"Comma-separated list of custom tags for this release (e.g., production,stable,lts).")
+	flags.BoolVar(&scaffoldChangelog, "changelog", false,
+		"Add an entry for the new version to the changelog (CHANGELOG.md, CHANGES, debian/changelog, ...), pre-filled with the commit subjects since the previous release.")
 	flags.BoolVar(&skipLint, "skip-lint", false,
 		"Skips validation of the release, use at your own risk for broken builds.")
 
@@ -18,13 +18,21 @@ import (
 type planStatus int
 
 const (
-	foundPlan      planStatus = iota // 0
-	repoError                        // 1
-	noPlanInCommit                   // 2
+	foundPlan        planStatus = iota // 0
+	repoError                          // 1
+	noPlanInCommit                     // 2
+	notesOutputError                   // 3, a plan was found but its release notes could not be written
 )
 
+// planOutputs are the optional files read-plan writes the plan and its release notes to
+type planOutputs struct {
+	jsonPath    string
+	notesPath   string
+	notesFormat string
+}
+
 func getReadPlanCommand() *cobra.Command {
-	var jsonOutputPath string
+	var outputs planOutputs
 	var commitMessage string
 
 	cmd := &cobra.Command{
@@ -35,28 +43,36 @@ and returning an error status if no plan was detected.
 
 Path doesn't need to be to a specific module, it can be to the repo itself.
 
-Useful for using as part of a conditional pipeline check.`,
+Useful for using as part of a conditional pipeline check.
+
+With --notes-output the release notes of all the modules of the plan are combined in a single document
+(markdown or json): the changelog section of each version and the commits since the previous release.`,
 		PreRunE: validateAllPathFlags,
 		Run: func(_ *cobra.Command, _ []string) {
 			repositoryRoot := viper.GetString("repoRoot")
-			retCode, err := readReleasePlan(repositoryRoot, jsonOutputPath, commitMessage)
-			if err != nil {
+			retCode, err := readReleasePlan(repositoryRoot, commitMessage, &outputs)
+			switch {
+			case err != nil && retCode == notesOutputError:
+				log.Error("error writing release notes of the plan", "error", err)
+			case err != nil:
 				log.Error("error reading release plan", "error", err)
 			}
 			os.Exit(int(retCode))
 		},
 	}
 
-	cmd.Flags().StringVar(&jsonOutputPath, "json-output", "", "If provided the plan will be written to that path")
+	cmd.Flags().StringVar(&outputs.jsonPath, "json-output", "", "If provided the plan will be written to that path")
+	cmd.Flags().StringVar(&outputs.notesPath, "notes-output", "", "If provided the release notes of the plan will be written to that path")
+	cmd.Flags().StringVar(&outputs.notesFormat, "notes-format", "md", "Format of the release notes: md or json")
 	cmd.Flags().StringVar(&commitMessage, "commit-message", "", "Read release plan from this string instead of git")
 
 	return cmd
 }
 
 // readReleasePlan attempts to read a release plan from the last commit, displaying its content if found.
-// Returns a return code of 0 if a plan was found, and 2 if not.
-// Optionally outputs a machine readable plan in json at the given path
-func readReleasePlan(repoRoot, jsonOutputPath, commitMessage string) (planStatus, error) {
+// Returns a return code of 0 if a plan was found, 2 if not and 3 if the release notes of the plan could not be written.
+// Optionally outputs a machine readable plan in json and the release notes of the plan at the given paths
+func readReleasePlan(repoRoot, commitMessage string, outputs *planOutputs) (planStatus, error) {
 	if commitMessage == "" {
 		log.Debug("no commit message passed in, attempting to read from HEAD with git")
 		headCommitMessage, err := getHeadCommitMessage(repoRoot)
@@ -82,21 +98,49 @@ func readReleasePlan(repoRoot, jsonOutputPath, commitMessage string) (planStatus
 		log.Info("- ", "target", target.Marshal())
 	}
 
-	if jsonOutputPath != "" {
+	if outputs.jsonPath != "" {
 		releasesJSON, err := json.Marshal(rp.Releases)
 		if err != nil {
 			return repoError, err
 		}
-		err = os.WriteFile(jsonOutputPath, releasesJSON, 0600)
+		err = os.WriteFile(outputs.jsonPath, releasesJSON, 0600)
 		if err != nil {
 			return repoError, err
 		}
-		log.Debug("release plan written to", "outputPath", jsonOutputPath)
+		log.Debug("release plan written to", "outputPath", outputs.jsonPath)
+	}
+
+	if outputs.notesPath != "" {
+		err = writeReleasePlanNotes(repoRoot, rp, outputs.notesPath, outputs.notesFormat)
+		if err != nil {
+			return notesOutputError, fmt.Errorf("failed to write release notes to %s: %w", outputs.notesPath, err)
+		}
+		log.Debug("release notes written to", "outputPath", outputs.notesPath)
 	}
 
 	return foundPlan, nil
 }
 
+func writeReleasePlanNotes(repoRoot string, rp *actions.ReleasePlan, outputPath, format string) error {
+	planNotes, err := actions.GetReleasePlanNotes(repoRoot, rp)
+	if err != nil {
+		return fmt.Errorf("failed to gather release notes: %w", err)
+	}
+	var notes string
+	switch format {
+	case "md":
+		notes = planNotes.ToMarkdown()
+	case "json":
+		notes, err = planNotes.ToJSON()
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported notes format: %s (supported: md, json)", format)
+	}
+	return os.WriteFile(outputPath, []byte(notes), 0600)
+}
+
 func getHeadCommitMessage(repoRoot string) (string, error) {
 	headCommitMessage, err := git.GetCommitMessageFromRef(repoRoot, "HEAD")
 	if err != nil {
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-ch/kaeter/mocks"
+)
+
+func TestReadReleasePlanNotesOutput(t *testing.T) {
+	const commitMessage = "release\n\nRelease-Plan: ch.open.kaeter:unit-test:0.0.0\n"
+	var tests = []struct {
+		name           string
+		notesPath      func(outputFolder string) string
+		expectedStatus planStatus
+		expectedError  string
+	}{
+		{
+			name:           "Writes the notes of the plan",
+			notesPath:      func(outputFolder string) string { return filepath.Join(outputFolder, "notes.md") },
+			expectedStatus: foundPlan,
+		},
+		{
+			name:           "Fails with the path of unwritable notes",
+			notesPath:      func(outputFolder string) string { return filepath.Join(outputFolder, "missing", "notes.md") },
+			expectedStatus: notesOutputError,
+			expectedError:  "failed to write release notes to ",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repoRoot, _ := mocks.CreateMockRepo(t)
+			mocks.CreateKaeterModule(t, repoRoot, &mocks.KaeterModuleConfig{
+				Path:         "module",
+				Makefile:     mocks.EmptyMakefileContent,
+				VersionsYAML: mocks.EmptyVersionsYAML,
+			})
+			notesPath := tc.notesPath(t.TempDir())
+
+			status, err := readReleasePlan(repoRoot, commitMessage, &planOutputs{notesPath: notesPath, notesFormat: "md"})
+
+			assert.Equal(t, tc.expectedStatus, status)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError+notesPath)
+				return
+			}
+			assert.NoError(t, err)
+			assert.FileExists(t, notesPath)
+		})
+	}
+}
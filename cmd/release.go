@@ -2,19 +2,33 @@ package cmd
 
 import (
 	"fmt"
+	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/open-ch/kaeter/actions"
+	"github.com/open-ch/kaeter/git"
 	"github.com/open-ch/kaeter/log"
 )
 
+// defaultJournalName is the file name of the release journal in the .git folder of the repository
+const defaultJournalName = "kaeter-release-journal.json"
+
 func getReleaseCommand() *cobra.Command {
 	var really bool
 	var nocheckout bool
 	var skipModules []string
 	var commitMessage string
+	var journalPath string
+	var resume bool
+	var continueOnError bool
+	var reportJSONPath string
+	var reportJUnitPath string
+	var parallel int
+	var targetTimeout time.Duration
+	var logDir string
 
 	cmd := &cobra.Command{
 		Use:   "release",
@@ -22,12 +36,23 @@ func getReleaseCommand() *cobra.Command {
 		Long: `Executes a release plan: currently such a plan can only be provided via the last commit in the repository
 on which kaeter is being run. See kaeter's doc for more details.'`,
 		PreRunE: validateAllPathFlags,
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(cmd *cobra.Command, _ []string) error {
 			if !really {
 				log.Warn("'really' flag is set to false: will run build and tests but no release.")
 			}
 			if !nocheckout {
-				log.Warn("'nocheckout' flag is set to false: will checkout the commit hash corresponding to the version of the module.")
+				log.Warn("'nocheckout' flag is set to false: will release from a worktree of the commit hash corresponding to the version of the module.")
+			}
+
+			if !cmd.Flags().Changed("journal") {
+				// Kept in the git folder so that the journal never shows up as a change of the checkout
+				gitDir, err := git.AbsoluteGitDir(viper.GetString("repoRoot"))
+				if err != nil {
+					return fmt.Errorf("unable to find git folder for the release journal: %s\n%w", gitDir, err)
+				}
+				journalPath = filepath.Join(gitDir, defaultJournalName)
+			} else if journalPath != "" && !filepath.IsAbs(journalPath) {
+				journalPath = filepath.Join(viper.GetString("repoRoot"), journalPath)
 			}
 
 			releaseConfig := &actions.ReleaseConfig{
@@ -37,8 +62,16 @@ on which kaeter is being run. See kaeter's doc for more details.'`,
 				SkipCheckout:         nocheckout,
 				SkipModules:          skipModules,
 				ReleaseCommitMessage: commitMessage,
+				JournalPath:          journalPath,
+				Resume:               resume,
+				ContinueOnError:      continueOnError,
+				ReportJSONPath:       reportJSONPath,
+				ReportJUnitPath:      reportJUnitPath,
+				Parallel:             parallel,
+				TargetTimeout:        targetTimeout,
+				LogDir:               logDir,
 			}
-			err := actions.RunReleases(releaseConfig)
+			err := actions.RunReleases(cmd.Context(), releaseConfig)
 			if err != nil {
 				return fmt.Errorf("release failed: %w", err)
 			}
@@ -51,11 +84,31 @@ on which kaeter is being run. See kaeter's doc for more details.'`,
 		`If set, and if the module is using SemVer, causes a bump in the minor version of the released module.
 By default the build number is incremented.`)
 	flags.BoolVar(&nocheckout, "nocheckout", false,
-		`If set, no checkout of the commit hash corresopnding to the version of the module will be made before
-releasing.`)
+		`If set, the module is released from the current checkout instead of a temporary worktree of the commit hash
+corresponding to the version of the module.`)
 	flags.StringArrayVar(&skipModules, "skip-module", []string{}, "List of kaeter module IDs to skip even if present in release plan")
 	flags.StringVar(&commitMessage, "commit-message", "", "Read release plan from this string instead of git")
 
+	flags.StringVar(&journalPath, "journal", "",
+		`Path (relative to the repository root) of the journal recording the state of each release target,
+`+defaultJournalName+` in the .git folder of the repository by default. Set to an empty string to disable the journal.`)
+	flags.BoolVar(&resume, "resume", false,
+		"Resume a failed release from the journal, skipping targets which were already completed.")
+	flags.BoolVar(&continueOnError, "continue-on-error", false,
+		"Attempt to release all targets of the plan even if one fails, the command still fails if any target failed.")
+	flags.StringVar(&reportJSONPath, "report-json", "", "If provided a JSON summary of the release will be written to that path")
+	flags.StringVar(&reportJUnitPath, "report-junit", "", "If provided a JUnit XML summary of the release will be written to that path")
+
+	flags.IntVar(&parallel, "parallel", 1,
+		`Maximum number of independent modules to release at once, modules are still released after the modules
+they depend on.`)
+
+	flags.DurationVar(&targetTimeout, "timeout", 0,
+		`Default timeout of each make target (i.e. 30m), modules can override it with annotations (see README),
+no timeout by default.`)
+
+	flags.StringVar(&logDir, "log-dir", "", "If provided the output of each make target is saved to a file in that folder")
+
 	cmd.MarkFlagsMutuallyExclusive("really", "commit-message")
 
 	return cmd
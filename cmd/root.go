@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/open-ch/kaeter/git"
 	"github.com/open-ch/kaeter/log"
+	"github.com/open-ch/kaeter/process"
 
 	charmlog "github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
@@ -71,12 +73,18 @@ Multiple paths can be passed for subcommands that support it.`)
 	rootCmd.AddCommand(getInventorizeCommand())
 	rootCmd.AddCommand(getModuleCommand())
 	rootCmd.AddCommand(getNeedsReleaseCommand())
+	rootCmd.AddCommand(getNotesCommand())
 	rootCmd.AddCommand(getLintCommand())
 	rootCmd.AddCommand(getPrepareCommand())
 	rootCmd.AddCommand(getReadPlanCommand())
 	rootCmd.AddCommand(getReleaseCommand())
+	rootCmd.AddCommand(getTagsSubCommands())
 
-	return rootCmd.Execute()
+	// Make targets and hooks are interrupted on SIGINT/SIGTERM rather than kaeter exiting right away
+	// so that releases can clean up (i.e. remove their worktree) and record their state.
+	ctx, stop := process.NotifyContext(context.Background())
+	defer stop()
+	return rootCmd.ExecuteContext(ctx)
 }
 
 func initializeConfig(cmd *cobra.Command) error {
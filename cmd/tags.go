@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/open-ch/kaeter/actions"
+)
+
+func getTagsSubCommands() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "tags",
+		Short: "Groups the git tags sub-commands",
+	}
+
+	command.AddCommand(getTagsSyncCommand())
+
+	return command
+}
+
+func getTagsSyncCommand() *cobra.Command {
+	var checkOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Creates and verifies the git tags of released versions",
+		Long: `For every module of the repository using git tags (open.ch/kaeter-git-tag annotation or
+git.tag.template in the config) the tags of all the released versions in versions.yaml are created
+if missing. Existing tags must point to the commit of the version, they are never moved.
+
+With --check the tags are only verified, missing tags are reported as errors.
+Tags are created locally, push them with git push --tags.`,
+		PreRunE: validateAllPathFlags,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return actions.SyncGitTags(&actions.TagsSyncConfig{
+				RepositoryRoot: viper.GetString("repoRoot"),
+				CheckOnly:      checkOnly,
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&checkOnly, "check", false, "Only verify the tags, fail if any is missing or points to another commit")
+
+	return cmd
+}
@@ -35,6 +35,28 @@ func ResetHard(repoPath, ref string) (string, error) {
 	return git(repoPath, "reset", "--hard", ref)
 }
 
+// WorktreeAdd creates a new detached worktree at path checked out at the given ref
+//
+//	git.WorktreeAdd("path/to/repo", "/tmp/kaeter-worktree", "d69928b5a74f70f6000db39d63d84e0aa2aa8ec9")
+func WorktreeAdd(repoPath, path, ref string) (string, error) {
+	return git(repoPath, "worktree", "add", "--detach", path, ref)
+}
+
+// WorktreeRemove removes a worktree previously created with WorktreeAdd, discarding
+// any changes in it.
+//
+//	git.WorktreeRemove("path/to/repo", "/tmp/kaeter-worktree")
+func WorktreeRemove(repoPath, path string) (string, error) {
+	return git(repoPath, "worktree", "remove", "--force", path)
+}
+
+// WorktreePrune removes the administrative data of worktrees whose folder was deleted
+//
+//	git.WorktreePrune("path/to/repo")
+func WorktreePrune(repoPath string) (string, error) {
+	return git(repoPath, "worktree", "prune")
+}
+
 // RestoreFile allows restoring a single file or path. The underlying call is
 // git restore --staged --worktree path/to/file
 // which will restore the file whether it is staged or unstaged.
@@ -52,6 +74,61 @@ func LogOneLine(repoPath, revisionRange string, paths ...string) (string, error)
 	return git(repoPath, "log", append([]string{"--oneline", revisionRange, "--"}, paths...)...)
 }
 
+// LsFiles lists the given paths which are tracked by git, the output is empty if none is
+//
+//	git.LsFiles("path/to/repo", "tools/hook.sh")
+func LsFiles(repoPath string, paths ...string) (string, error) {
+	return git(repoPath, "ls-files", append([]string{"--"}, paths...)...)
+}
+
+// StatusPorcelain lists the changes of the given paths in the stable short format,
+// the output is empty if they are unmodified (or ignored).
+//
+//	git.StatusPorcelain("path/to/repo", "tools/hook.sh")
+func StatusPorcelain(repoPath string, paths ...string) (string, error) {
+	return git(repoPath, "status", append([]string{"--porcelain", "--"}, paths...)...)
+}
+
+// TagAnnotated creates an annotated tag pointing to the given ref
+//
+//	git.TagAnnotated("path/to/repo", "my-module/v1.0.0", "Release my-module 1.0.0", "d69928b5a74f70f6000db39d63d84e0aa2aa8ec9")
+func TagAnnotated(repoPath, name, message, ref string) (string, error) {
+	return git(repoPath, "tag", "--annotate", "--message", message, name, ref)
+}
+
+// CheckRefFormat validates that the given name can be used as a git ref
+//
+//	git.CheckRefFormat("path/to/repo", "refs/tags/my-module/v1.0.0")
+func CheckRefFormat(repoPath, ref string) error {
+	output, err := git(repoPath, "check-ref-format", ref)
+	if err != nil {
+		return fmt.Errorf("invalid git ref %s: %s%w", ref, output, err)
+	}
+	return nil
+}
+
+// ConfigGet returns the value of a git config key, i.e. user.name
+//
+//	git.ConfigGet("path/to/repo", "user.email")
+func ConfigGet(repoPath, key string) (string, error) {
+	output, err := git(repoPath, "config", "--get", key)
+	return strings.TrimSpace(output), err
+}
+
+// Author returns the configured git user as Name <email>, empty if not configured
+//
+//	git.Author("path/to/repo")
+func Author(repoPath string) string {
+	name, err := ConfigGet(repoPath, "user.name")
+	if err != nil || name == "" {
+		return ""
+	}
+	if email, err := ConfigGet(repoPath, "user.email"); err == nil && email != "" {
+		return name + " <" + email + ">"
+	}
+	return name
+}
+
 // BranchContains is a shortcut to check
 //
 //	git.BranchContains("path/to/repo", "commit_hash", "branch_pattern")
@@ -86,6 +163,17 @@ func ShowTopLevel(repoPath string) (string, error) {
 	return strings.TrimSuffix(output, "\n"), nil
 }
 
+// AbsoluteGitDir finds the .git folder of a git repo given a path, the one of the worktree for linked worktrees
+// see https://git-scm.com/docs/git-rev-parse#Documentation/git-rev-parse.txt---absolute-git-dir for more details
+func AbsoluteGitDir(repoPath string) (string, error) {
+	output, err := git(repoPath, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return output, err
+	}
+
+	return strings.TrimSuffix(output, "\n"), nil
+}
+
 // GetCommitMessageFromRef returns the commit message (raw body) from the given commit revision
 // or hash.
 // see https://git-scm.com/docs/git-log for more details
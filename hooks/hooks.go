@@ -4,14 +4,33 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/open-ch/kaeter/modules"
+	"github.com/open-ch/kaeter/process"
 )
 
 const annotationPrefix = "open.ch/kaeter-hook/"
 
+// Lifecycle hooks run around releases, they get the release context as KAETER_* environment variables.
+const (
+	// PrePrepare runs before a release is requested by prepare or autorelease, failing aborts the request
+	PrePrepare = "pre-prepare"
+	// PreRelease runs before the release steps of a module, failing aborts the release of the module
+	PreRelease = "pre-release"
+	// PostRelease runs once all the release steps of a module succeeded
+	PostRelease = "post-release"
+	// OnReleaseFailure runs when the pre-release hook or a release step of a module failed
+	OnReleaseFailure = "on-release-failure"
+)
+
+// timeoutAnnotationPrefix allows limiting how long a hook may run, as a go duration
+// i.e. open.ch/kaeter-hook-timeout/autorelease-version: 30s
+const timeoutAnnotationPrefix = "open.ch/kaeter-hook-timeout/"
+
 // HasHook checks if the module has an annotation defining the named hook
 func HasHook(hookName string, module *modules.Versions) bool {
 	if module == nil || module.Metadata == nil {
@@ -26,32 +45,80 @@ func HasHook(hookName string, module *modules.Versions) bool {
 // successful. A list of arguments can be passed in.
 //
 // The value of the hook must be an executable with a path relative to the repository root.
-func RunHook(hookName string, module *modules.Versions, repositoryRoot string, arguments []string) (string, error) {
+// The hook is interrupted when the context is cancelled or its configured timeout is reached.
+func RunHook(ctx context.Context, hookName string, module *modules.Versions, repositoryRoot string, arguments []string) (string, error) {
+	return RunHookWithEnv(ctx, hookName, module, repositoryRoot, arguments, nil)
+}
+
+// RunHookWithEnv executes the hook like RunHook with the given variables added to its environment.
+func RunHookWithEnv(
+	ctx context.Context,
+	hookName string,
+	module *modules.Versions,
+	repositoryRoot string,
+	arguments []string,
+	env []string,
+) (string, error) {
+	output, err := runHook(ctx, hookName, module, repositoryRoot, arguments, env, nil)
+	return strings.TrimSpace(string(output)), err
+}
+
+// runHook executes the hook with the given arguments, environment and stdin, returning its raw stdout.
+func runHook(
+	ctx context.Context,
+	hookName string,
+	module *modules.Versions,
+	repositoryRoot string,
+	arguments []string,
+	env []string,
+	stdin io.Reader,
+) ([]byte, error) {
 	if module == nil || module.Metadata == nil {
-		return "", errors.New("kaeter module has no annotations available")
+		return nil, errors.New("kaeter module has no annotations available")
 	}
 	annotationName := annotationPrefix + hookName
 	hookPath, hookExists := module.Metadata.Annotations[annotationName]
 
 	if !hookExists {
-		return "", errors.New("kaeter module has no annotations available")
+		return nil, errors.New("kaeter module has no annotations available")
 	}
 
-	// Reject path traversal in hooks
-	if strings.Contains(hookPath, "..") {
-		// Note we might use https://go.dev/blog/osroot for a more accurate setup and avoid false positives.
-		// and limit the path to only in repo.
-		return "", errors.New("path traversal not allowed in hooks, use relative local paths only")
+	executablePath, err := resolveHook(repositoryRoot, hookPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s hook rejected: %w", hookName, err)
 	}
+	timeout, err := hookTimeout(hookName, module)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := process.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	//nosemgrep: dangerous-exec-command,go_subproc_rule-subproc
-	hookCmd := exec.CommandContext(context.TODO(), hookPath, arguments...)
+	hookCmd := process.Command(ctx, executablePath, arguments...)
 	hookCmd.Dir = repositoryRoot
+	hookCmd.Stdin = stdin
+	hookCmd.Env = hookEnvironment(env)
 	output, err := hookCmd.Output()
+	err = process.Describe(ctx, err, timeout)
 	if err != nil {
 		if exitErr, ok := errors.AsType[*exec.ExitError](err); ok {
-			return "", fmt.Errorf("execution of %s hook failed with the following error:\n%s\n%w", hookName, exitErr.Stderr, err)
+			return nil, fmt.Errorf("execution of %s hook failed with the following error:\n%s\n%w", hookName, exitErr.Stderr, err)
 		}
-		return "", fmt.Errorf("execution of %s hook failed with the following error:\n%s\n%w", hookName, output, err)
+		return nil, fmt.Errorf("execution of %s hook failed with the following error:\n%s\n%w", hookName, output, err)
+	}
+	return output, nil
+}
+
+// hookTimeout returns the timeout configured for the hook, 0 if none is configured.
+func hookTimeout(hookName string, module *modules.Versions) (time.Duration, error) {
+	value, found := module.Metadata.Annotations[timeoutAnnotationPrefix+hookName]
+	if !found {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout for %s hook: %w", hookName, err)
 	}
-	return strings.TrimSpace(string(output)), nil
+	return timeout, nil
 }
@@ -1,7 +1,10 @@
 package hooks
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -107,7 +110,7 @@ func TestRunHook(t *testing.T) {
 			repositoryRoot := "."
 			additionalArguments := []string{"one", "two", "3"}
 
-			result, err := RunHook("test-hook", tc.kaeterModule, repositoryRoot, additionalArguments)
+			result, err := RunHook(t.Context(), "test-hook", tc.kaeterModule, repositoryRoot, additionalArguments)
 
 			if tc.expectError {
 				assert.Error(t, err)
@@ -118,3 +121,53 @@ func TestRunHook(t *testing.T) {
 		})
 	}
 }
+
+func TestRunHookTimeout(t *testing.T) {
+	repositoryRoot := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(repositoryRoot, "hooks"), 0700))
+	//nolint:gosec // the hook needs to be executable
+	assert.NoError(t, os.WriteFile(filepath.Join(repositoryRoot, "hooks", "sleep.sh"), []byte("#!/bin/sh\nsleep 30\n"), 0700))
+	var tests = []struct {
+		name          string
+		timeout       string
+		expectedError string
+	}{
+		{name: "Interrupted after timeout", timeout: "100ms", expectedError: "timed out after 100ms"},
+		{name: "Invalid timeout", timeout: "soon", expectedError: "invalid timeout for test-hook hook"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kaeterModule := &modules.Versions{
+				Metadata: &modules.Metadata{
+					Annotations: map[string]string{
+						"open.ch/kaeter-hook/test-hook":         "hooks/sleep.sh",
+						"open.ch/kaeter-hook-timeout/test-hook": tc.timeout,
+					},
+				},
+			}
+			start := time.Now()
+
+			_, err := RunHook(t.Context(), "test-hook", kaeterModule, repositoryRoot, nil)
+
+			assert.ErrorContains(t, err, tc.expectedError)
+			assert.Less(t, time.Since(start), 10*time.Second)
+		})
+	}
+}
+
+func TestRunHookWithEnv(t *testing.T) {
+	repositoryRoot := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(repositoryRoot, "hooks"), 0700))
+	//nolint:gosec // the hook needs to be executable
+	assert.NoError(t, os.WriteFile(filepath.Join(repositoryRoot, "hooks", "env.sh"), []byte("#!/bin/sh\necho \"$KAETER_VERSION $1\"\n"), 0700))
+	kaeterModule := &modules.Versions{
+		Metadata: &modules.Metadata{
+			Annotations: map[string]string{"open.ch/kaeter-hook/post-release": "hooks/env.sh"},
+		},
+	}
+
+	output, err := RunHookWithEnv(t.Context(), PostRelease, kaeterModule, repositoryRoot, []string{"arg"}, []string{"KAETER_VERSION=1.2.3"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3 arg", output)
+}
@@ -0,0 +1,160 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/open-ch/kaeter/modules"
+)
+
+// protocolAnnotationPrefix selects how a hook communicates with kaeter, either
+// ProtocolPositional (the default) or ProtocolJSON, i.e. open.ch/kaeter-hook-protocol/autorelease-version: json
+const protocolAnnotationPrefix = "open.ch/kaeter-hook-protocol/"
+
+const (
+	// ProtocolPositional hooks get arguments and return their trimmed stdout as a single value
+	ProtocolPositional = "positional"
+	// ProtocolJSON hooks read a Request as JSON from stdin and write a Response as JSON to stdout
+	ProtocolJSON = "json"
+)
+
+// JSONProtocolVersion is sent in every Request, it is increased on breaking changes of the documents
+const JSONProtocolVersion = 1
+
+const (
+	// VerdictPass lets kaeter carry on
+	VerdictPass = "pass"
+	// VerdictFail makes kaeter abort what the hook was called for
+	VerdictFail = "fail"
+)
+
+// Request is the document written to the stdin of hooks using the JSON protocol.
+type Request struct {
+	ProtocolVersion int              `json:"protocolVersion"`
+	Hook            string           `json:"hook"`
+	Module          RequestModule    `json:"module"`
+	Versions        []RequestVersion `json:"versions"`
+	Target          *RequestTarget   `json:"target,omitempty"`
+	Changeset       *Changeset       `json:"changeset,omitempty"`
+	Error           string           `json:"error,omitempty"` // Why the release failed, for on-release-failure
+}
+
+// RequestModule describes the module the hook runs for.
+type RequestModule struct {
+	ID          string            `json:"id"`
+	Path        string            `json:"path"` // relative to the repository root
+	Type        string            `json:"type"`
+	Versioning  string            `json:"versioning"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// RequestVersion is an entry of the versions.yaml of the module.
+type RequestVersion struct {
+	Number    string    `json:"number"`
+	Timestamp time.Time `json:"timestamp"`
+	Commit    string    `json:"commit"`
+	Tags      []string  `json:"tags,omitempty"`
+}
+
+// RequestTarget is the release the hook runs for, not set for hooks running before a version is known.
+type RequestTarget struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	DryRun  bool   `json:"dryRun"`
+}
+
+// Changeset lists the changes of the module since its previous release.
+type Changeset struct {
+	Since   string   `json:"since"`   // commit of the previous release
+	Until   string   `json:"until"`   // commit (or ref) of the release
+	Commits []string `json:"commits"` // one line per commit: abbreviated hash and subject
+	Files   []string `json:"files"`   // added, modified or removed files relative to the repository root
+}
+
+// Response is the document hooks using the JSON protocol write to stdout.
+type Response struct {
+	Version  string   `json:"version,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Messages []string `json:"messages,omitempty"`
+	Verdict  string   `json:"verdict"`
+}
+
+// NewRequest returns a request describing the module and its versions.
+func NewRequest(module *modules.Versions, modulePath string) *Request {
+	request := &Request{
+		ProtocolVersion: JSONProtocolVersion,
+		Module: RequestModule{
+			ID:         module.ID,
+			Path:       modulePath,
+			Type:       module.ModuleType,
+			Versioning: module.VersioningType,
+		},
+		Versions: make([]RequestVersion, 0, len(module.ReleasedVersions)),
+	}
+	if module.Metadata != nil {
+		request.Module.Annotations = module.Metadata.Annotations
+	}
+	for _, version := range module.ReleasedVersions {
+		request.Versions = append(request.Versions, RequestVersion{
+			Number:    version.Number.String(),
+			Timestamp: version.Timestamp,
+			Commit:    version.CommitID,
+			Tags:      version.Tags,
+		})
+	}
+	return request
+}
+
+// Protocol returns the protocol the named hook of the module uses.
+func Protocol(hookName string, module *modules.Versions) (string, error) {
+	if module == nil || module.Metadata == nil {
+		return ProtocolPositional, nil
+	}
+	protocol, found := module.Metadata.Annotations[protocolAnnotationPrefix+hookName]
+	switch {
+	case !found, protocol == ProtocolPositional:
+		return ProtocolPositional, nil
+	case protocol == ProtocolJSON:
+		return ProtocolJSON, nil
+	default:
+		return "", fmt.Errorf("unknown protocol '%s' for %s hook, expected %s or %s", protocol, hookName, ProtocolPositional, ProtocolJSON)
+	}
+}
+
+// RunJSONHook executes the hook writing the request to its stdin and returns the response
+// it wrote to stdout. A fail verdict is returned as an error along with the response.
+func RunJSONHook(
+	ctx context.Context,
+	hookName string,
+	module *modules.Versions,
+	repositoryRoot string,
+	request *Request,
+	env []string,
+) (*Response, error) {
+	request.Hook = hookName
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode request for %s hook: %w", hookName, err)
+	}
+	output, err := runHook(ctx, hookName, module, repositoryRoot, nil, env, bytes.NewReader(requestJSON))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &Response{}
+	if err := json.Unmarshal(output, response); err != nil {
+		return nil, fmt.Errorf("invalid response from %s hook: %w\n%s", hookName, err, output)
+	}
+	switch response.Verdict {
+	case VerdictPass:
+		return response, nil
+	case VerdictFail:
+		return response, fmt.Errorf("%s hook failed: %s", hookName, strings.Join(response.Messages, "; "))
+	default:
+		return nil, fmt.Errorf("invalid verdict '%s' from %s hook, expected %s or %s", response.Verdict, hookName, VerdictPass, VerdictFail)
+	}
+}
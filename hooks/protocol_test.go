@@ -0,0 +1,143 @@
+package hooks
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-ch/kaeter/modules"
+)
+
+func TestProtocol(t *testing.T) {
+	var tests = []struct {
+		name             string
+		annotations      map[string]string
+		expectedProtocol string
+		expectError      bool
+	}{
+		{
+			name:             "Defaults to positional",
+			annotations:      map[string]string{},
+			expectedProtocol: ProtocolPositional,
+		},
+		{
+			name:             "JSON opt-in",
+			annotations:      map[string]string{"open.ch/kaeter-hook-protocol/test-hook": "json"},
+			expectedProtocol: ProtocolJSON,
+		},
+		{
+			name:             "Other hooks are not affected",
+			annotations:      map[string]string{"open.ch/kaeter-hook-protocol/other-hook": "json"},
+			expectedProtocol: ProtocolPositional,
+		},
+		{
+			name:        "Fails for unknown protocol",
+			annotations: map[string]string{"open.ch/kaeter-hook-protocol/test-hook": "xml"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			module := &modules.Versions{Metadata: &modules.Metadata{Annotations: tc.annotations}}
+
+			protocol, err := Protocol("test-hook", module)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedProtocol, protocol)
+		})
+	}
+}
+
+func TestRunJSONHook(t *testing.T) {
+	var tests = []struct {
+		name             string
+		response         string
+		expectedResponse *Response
+		expectError      bool
+	}{
+		{
+			name:     "Passing hook with version and tags",
+			response: `{"version": "1.2.0", "tags": ["latest"], "messages": ["minor bump"], "verdict": "pass"}`,
+			expectedResponse: &Response{
+				Version:  "1.2.0",
+				Tags:     []string{"latest"},
+				Messages: []string{"minor bump"},
+				Verdict:  VerdictPass,
+			},
+		},
+		{
+			name:             "Failing verdict returns the response and an error",
+			response:         `{"messages": ["version already published"], "verdict": "fail"}`,
+			expectedResponse: &Response{Messages: []string{"version already published"}, Verdict: VerdictFail},
+			expectError:      true,
+		},
+		{
+			name:        "Fails without verdict",
+			response:    `{"version": "1.2.0"}`,
+			expectError: true,
+		},
+		{
+			name:        "Fails for invalid JSON",
+			response:    `1.2.0`,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repositoryRoot := t.TempDir()
+			assert.NoError(t, os.Mkdir(filepath.Join(repositoryRoot, "hooks"), 0700))
+			hookContent := "#!/bin/sh\ncat > request.json\necho '" + tc.response + "'\n"
+			//nolint:gosec // the hook needs to be executable
+			assert.NoError(t, os.WriteFile(filepath.Join(repositoryRoot, "hooks", "json.sh"), []byte(hookContent), 0700))
+			module := &modules.Versions{
+				ID:             "ch.open.kaeter:unit-test",
+				ModuleType:     "Makefile",
+				VersioningType: "SemVer",
+				Metadata: &modules.Metadata{
+					Annotations: map[string]string{"open.ch/kaeter-hook/test-hook": "hooks/json.sh"},
+				},
+				ReleasedVersions: []*modules.VersionMetadata{
+					{Number: modules.NewVersion(1, 1, 0), Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), CommitID: "deadbeef"},
+				},
+			}
+			request := NewRequest(module, "module")
+			request.Changeset = &Changeset{Since: "deadbeef", Until: "HEAD", Commits: []string{"abc1234 feat: add"}, Files: []string{"module/main.go"}}
+
+			response, err := RunJSONHook(t.Context(), "test-hook", module, repositoryRoot, request, nil)
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedResponse, response)
+			requestJSON, err := os.ReadFile(filepath.Join(repositoryRoot, "request.json"))
+			assert.NoError(t, err)
+			receivedRequest := &Request{}
+			assert.NoError(t, json.Unmarshal(requestJSON, receivedRequest))
+			assert.Equal(t, JSONProtocolVersion, receivedRequest.ProtocolVersion)
+			assert.Equal(t, "test-hook", receivedRequest.Hook)
+			assert.Equal(t, RequestModule{
+				ID:          "ch.open.kaeter:unit-test",
+				Path:        "module",
+				Type:        "Makefile",
+				Versioning:  "SemVer",
+				Annotations: map[string]string{"open.ch/kaeter-hook/test-hook": "hooks/json.sh"},
+			}, receivedRequest.Module)
+			assert.Equal(t, []RequestVersion{
+				{Number: "1.1.0", Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Commit: "deadbeef"},
+			}, receivedRequest.Versions)
+			assert.Equal(t, request.Changeset, receivedRequest.Changeset)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package hooks
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/open-ch/kaeter/git"
+)
+
+const (
+	// envAllowlistViperKey lists the additional variables of the kaeter environment passed to hooks,
+	// names ending with * match any variable with that prefix.
+	envAllowlistViperKey = "hooks.envallowlist"
+	// requireTrackedViperKey requires hooks to be tracked by git and unmodified in the checkout.
+	requireTrackedViperKey = "hooks.requiretracked"
+)
+
+// defaultEnvAllowlist are the variables of the kaeter environment always passed to hooks
+var defaultEnvAllowlist = []string{ //nolint:gochecknoglobals
+	"PATH", "HOME", "USER", "LOGNAME", "SHELL", "TERM", "TZ", "TMPDIR", "LANG", "LC_*",
+}
+
+// resolveHook returns the absolute path of the hook executable. The hook path must be local (relative and
+// without ..) and resolve, symlinks included, to an executable file inside the repository root.
+// If required by the config the file must also be tracked by git and unmodified so that a change cannot
+// point a hook to a file it adds or edits.
+func resolveHook(repositoryRoot, hookPath string) (string, error) {
+	if !filepath.IsLocal(hookPath) {
+		return "", fmt.Errorf("hook path %s must be relative to the repository root and stay inside it", hookPath)
+	}
+	root, err := os.OpenRoot(repositoryRoot)
+	if err != nil {
+		return "", fmt.Errorf("unable to open repository root %s: %w", repositoryRoot, err)
+	}
+	defer root.Close()
+	// The root follows symlinks only as long as they stay inside the repository
+	info, err := root.Stat(hookPath)
+	if err != nil {
+		return "", fmt.Errorf("hook %s must be a file inside the repository: %w", hookPath, err)
+	}
+	if !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+		return "", fmt.Errorf("hook %s must be an executable file", hookPath)
+	}
+
+	resolvedRoot, err := filepath.EvalSymlinks(repositoryRoot)
+	if err != nil {
+		return "", err
+	}
+	resolvedRoot, err = filepath.Abs(resolvedRoot)
+	if err != nil {
+		return "", err
+	}
+	resolvedHook, err := filepath.EvalSymlinks(filepath.Join(resolvedRoot, hookPath))
+	if err != nil {
+		return "", err
+	}
+	relativeHook, err := filepath.Rel(resolvedRoot, resolvedHook)
+	if err != nil || !filepath.IsLocal(relativeHook) {
+		return "", fmt.Errorf("hook %s resolves outside of the repository", hookPath)
+	}
+
+	if viper.GetBool(requireTrackedViperKey) {
+		// Both the symlink, if the hook is one, and the file it points to
+		for _, path := range slices.Compact([]string{filepath.Clean(hookPath), relativeHook}) {
+			if err := checkTrackedAndUnmodified(resolvedRoot, path); err != nil {
+				return "", fmt.Errorf("hook %s: %w", hookPath, err)
+			}
+		}
+	}
+	return resolvedHook, nil
+}
+
+func checkTrackedAndUnmodified(repositoryRoot, path string) error {
+	output, err := git.LsFiles(repositoryRoot, path)
+	if err != nil {
+		return fmt.Errorf("unable to check if %s is tracked: %s\n%w", path, output, err)
+	}
+	if strings.TrimSpace(output) == "" {
+		return fmt.Errorf("%s is not tracked by git", path)
+	}
+	output, err = git.StatusPorcelain(repositoryRoot, path)
+	if err != nil {
+		return fmt.Errorf("unable to check if %s is modified: %s\n%w", path, output, err)
+	}
+	if strings.TrimSpace(output) != "" {
+		return errors.New(path + " has uncommitted changes")
+	}
+	return nil
+}
+
+// hookEnvironment returns the variables of the kaeter environment allowed for hooks followed by env.
+func hookEnvironment(env []string) []string {
+	allowlist := append(slices.Clone(defaultEnvAllowlist), viper.GetStringSlice(envAllowlistViperKey)...)
+	var hookEnv []string
+	for _, variable := range os.Environ() {
+		name, _, _ := strings.Cut(variable, "=")
+		if slices.ContainsFunc(allowlist, func(allowed string) bool { return envNameMatches(allowed, name) }) {
+			hookEnv = append(hookEnv, variable)
+		}
+	}
+	return append(hookEnv, env...)
+}
+
+func envNameMatches(allowed, name string) bool {
+	if prefix, isPattern := strings.CutSuffix(allowed, "*"); isPattern {
+		return strings.HasPrefix(name, prefix)
+	}
+	return allowed == name
+}
@@ -0,0 +1,118 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-ch/kaeter/mocks"
+)
+
+func createExecutable(t *testing.T, folder, name string) string {
+	t.Helper()
+	path := filepath.Join(folder, name)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	//nolint:gosec // the hook needs to be executable
+	assert.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\necho hook\n"), 0700))
+	return path
+}
+
+func TestResolveHook(t *testing.T) {
+	repositoryRoot := t.TempDir()
+	outsideFolder := t.TempDir()
+	hookPath := createExecutable(t, repositoryRoot, "hooks/hook.sh")
+	outsideHookPath := createExecutable(t, outsideFolder, "hook.sh")
+	mocks.CreateMockFile(t, repositoryRoot, "hooks/not-executable.sh", "#!/bin/sh\n")
+	assert.NoError(t, os.Symlink("hook.sh", filepath.Join(repositoryRoot, "hooks", "link.sh")))
+	assert.NoError(t, os.Symlink(outsideHookPath, filepath.Join(repositoryRoot, "hooks", "outside-link.sh")))
+	assert.NoError(t, os.Symlink(outsideFolder, filepath.Join(repositoryRoot, "outside")))
+	resolvedHookPath, err := filepath.EvalSymlinks(hookPath)
+	assert.NoError(t, err)
+
+	var tests = []struct {
+		name         string
+		hookPath     string
+		expectedPath string
+	}{
+		{name: "Resolves hook in repository", hookPath: "hooks/hook.sh", expectedPath: resolvedHookPath},
+		{name: "Resolves symlink inside repository", hookPath: "hooks/link.sh", expectedPath: resolvedHookPath},
+		{name: "Rejects absolute path", hookPath: outsideHookPath},
+		{name: "Rejects path traversal", hookPath: "../hook.sh"},
+		{name: "Rejects symlink to file outside of repository", hookPath: "hooks/outside-link.sh"},
+		{name: "Rejects path through symlink to folder outside of repository", hookPath: "outside/hook.sh"},
+		{name: "Rejects missing hook", hookPath: "hooks/missing.sh"},
+		{name: "Rejects folder", hookPath: "hooks"},
+		{name: "Rejects file which is not executable", hookPath: "hooks/not-executable.sh"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path, err := resolveHook(repositoryRoot, tc.hookPath)
+
+			if tc.expectedPath == "" {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedPath, path)
+		})
+	}
+}
+
+func TestResolveHookRequireTracked(t *testing.T) {
+	repositoryRoot, _ := mocks.CreateMockRepo(t)
+	createExecutable(t, repositoryRoot, "hooks/tracked.sh")
+	createExecutable(t, repositoryRoot, "hooks/modified.sh")
+	mocks.CommitFileAndGetHash(t, repositoryRoot, "hooks/README.md", "hooks", "Add hooks")
+	createExecutable(t, repositoryRoot, "hooks/untracked.sh")
+	mocks.CreateMockFile(t, repositoryRoot, ".gitignore", "ignored.sh\n")
+	createExecutable(t, repositoryRoot, "hooks/ignored.sh")
+	mocks.CreateMockFile(t, repositoryRoot, "hooks/modified.sh", "#!/bin/sh\necho modified\n")
+	assert.NoError(t, os.Symlink("untracked.sh", filepath.Join(repositoryRoot, "hooks", "link-to-untracked.sh")))
+	viper.Set(requireTrackedViperKey, true)
+	t.Cleanup(viper.Reset)
+
+	var tests = []struct {
+		name     string
+		hookPath string
+		valid    bool
+	}{
+		{name: "Accepts tracked hook", hookPath: "hooks/tracked.sh", valid: true},
+		{name: "Rejects untracked hook", hookPath: "hooks/untracked.sh"},
+		{name: "Rejects ignored hook", hookPath: "hooks/ignored.sh"},
+		{name: "Rejects modified hook", hookPath: "hooks/modified.sh"},
+		{name: "Rejects untracked symlink to untracked hook", hookPath: "hooks/link-to-untracked.sh"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := resolveHook(repositoryRoot, tc.hookPath)
+
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestHookEnvironment(t *testing.T) {
+	t.Setenv("PATH", "/usr/bin")
+	t.Setenv("LC_TIME", "C")
+	t.Setenv("SECRET_TOKEN", "secret")
+	t.Setenv("CI_JOB_ID", "42")
+	t.Setenv("CI_JOB_TOKEN", "token")
+	viper.Set(envAllowlistViperKey, []string{"CI_JOB_ID"})
+	t.Cleanup(viper.Reset)
+
+	env := hookEnvironment([]string{"KAETER_VERSION=1.0.0"})
+
+	assert.Contains(t, env, "PATH=/usr/bin")
+	assert.Contains(t, env, "LC_TIME=C")
+	assert.Contains(t, env, "CI_JOB_ID=42")
+	assert.Equal(t, "KAETER_VERSION=1.0.0", env[len(env)-1])
+	assert.NotContains(t, env, "SECRET_TOKEN=secret")
+	assert.NotContains(t, env, "CI_JOB_TOKEN=token")
+}
@@ -0,0 +1,226 @@
+package inventory
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/open-ch/kaeter/modules"
+)
+
+const (
+	backstageAPIVersion       = "backstage.io/v1alpha1"
+	backstageKind             = "Component"
+	backstageNameMaxLength    = 63
+	backstageAnnotationPrefix = "open.ch/kaeter-"
+)
+
+// Backstage entity names are limited to alphanumerics separated by [-_.]
+var backstageInvalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9\-_.]+`)
+
+// BackstageConfig controls how kaeter modules are mapped to backstage Component entities.
+// It is read from the backstage section of .kaeter.config.yaml:
+//
+//	backstage:
+//	  owner: team-platform
+//	  lifecycle: production
+//	  type: service
+//	  mappings:
+//	    - annotation: example.com/team
+//	      field: spec.owner
+type BackstageConfig struct {
+	// Defaults for required spec fields when no annotation mapping provides them.
+	Owner     string `mapstructure:"owner"`
+	Lifecycle string `mapstructure:"lifecycle"`
+	Type      string `mapstructure:"type"`
+	// Mappings from kaeter module annotations to backstage entity fields
+	Mappings []BackstageMapping `mapstructure:"mappings"`
+}
+
+// BackstageMapping maps a single kaeter annotation to a backstage field. Supported fields are
+// metadata.title, metadata.description, metadata.tags (comma separated), metadata.annotations.<key>,
+// metadata.labels.<key>, spec.owner, spec.lifecycle, spec.system and spec.type.
+type BackstageMapping struct {
+	Annotation string `mapstructure:"annotation"`
+	Field      string `mapstructure:"field"`
+}
+
+// BackstageEntity is the subset of a backstage catalog-info.yaml entity generated by kaeter.
+type BackstageEntity struct {
+	APIVersion string                  `yaml:"apiVersion"`
+	Kind       string                  `yaml:"kind"`
+	Metadata   BackstageEntityMetadata `yaml:"metadata"`
+	Spec       BackstageEntitySpec     `yaml:"spec"`
+}
+
+// BackstageEntityMetadata holds the metadata section of a backstage entity.
+type BackstageEntityMetadata struct {
+	Name        string            `yaml:"name"`
+	Title       string            `yaml:"title,omitempty"`
+	Description string            `yaml:"description,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Tags        []string          `yaml:"tags,omitempty"`
+}
+
+// BackstageEntitySpec holds the spec section of a backstage Component entity.
+type BackstageEntitySpec struct {
+	Type      string   `yaml:"type"`
+	Lifecycle string   `yaml:"lifecycle"`
+	Owner     string   `yaml:"owner"`
+	System    string   `yaml:"system,omitempty"`
+	DependsOn []string `yaml:"dependsOn,omitempty"`
+}
+
+// ToBackstage converts the inventory to a multi document YAML string of backstage
+// Component entities, one per module.
+func (i *Inventory) ToBackstage(config *BackstageConfig) (string, error) {
+	documents := make([]string, 0, len(i.ModuleInventory.Modules))
+	for idx := range i.ModuleInventory.Modules {
+		entity, err := i.toBackstageEntity(&i.ModuleInventory.Modules[idx], config)
+		if err != nil {
+			return "", err
+		}
+		bytes, err := yaml.Marshal(entity)
+		if err != nil {
+			return "", fmt.Errorf("could not marshal backstage entity for %s: %w", entity.Metadata.Name, err)
+		}
+		documents = append(documents, string(bytes))
+	}
+	return "---\n" + strings.Join(documents, "---\n"), nil
+}
+
+func (i *Inventory) toBackstageEntity(module *modules.KaeterModule, config *BackstageConfig) (*BackstageEntity, error) {
+	entity := &BackstageEntity{
+		APIVersion: backstageAPIVersion,
+		Kind:       backstageKind,
+		Metadata: BackstageEntityMetadata{
+			Name:  backstageEntityName(module.ModuleID),
+			Title: module.ModuleID,
+			Annotations: map[string]string{
+				backstageAnnotationPrefix + "module-id":   module.ModuleID,
+				backstageAnnotationPrefix + "module-path": module.ModulePath,
+			},
+		},
+		Spec: BackstageEntitySpec{
+			Type:      config.Type,
+			Lifecycle: config.Lifecycle,
+			Owner:     config.Owner,
+			DependsOn: i.backstageDependencies(module),
+		},
+	}
+	// The owners from CODEOWNERS (or the owner annotation) take precedence over the default owner, mappings over both
+	if len(module.Owners) > 0 {
+		entity.Spec.Owner = strings.TrimPrefix(module.Owners[0], "@")
+	}
+	if latestRelease := module.GetLatestRelease(); latestRelease != nil && latestRelease.CommitID != modules.InitRef {
+		entity.Metadata.Annotations[backstageAnnotationPrefix+"latest-version"] = latestRelease.Number.String()
+	}
+
+	for _, mapping := range config.Mappings {
+		value, found := module.Annotations[mapping.Annotation]
+		if !found {
+			continue
+		}
+		err := entity.setField(mapping.Field, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid backstage mapping for annotation %s: %w", mapping.Annotation, err)
+		}
+	}
+
+	return entity, nil
+}
+
+func (e *BackstageEntity) setField(field, value string) error {
+	if key, found := strings.CutPrefix(field, "metadata.annotations."); found {
+		e.Metadata.Annotations[key] = value
+		return nil
+	}
+	if key, found := strings.CutPrefix(field, "metadata.labels."); found {
+		if e.Metadata.Labels == nil {
+			e.Metadata.Labels = make(map[string]string)
+		}
+		e.Metadata.Labels[key] = value
+		return nil
+	}
+
+	switch field {
+	case "metadata.title":
+		e.Metadata.Title = value
+	case "metadata.description":
+		e.Metadata.Description = value
+	case "metadata.tags":
+		for tag := range strings.SplitSeq(value, ",") {
+			if trimmed := strings.TrimSpace(tag); trimmed != "" {
+				e.Metadata.Tags = append(e.Metadata.Tags, trimmed)
+			}
+		}
+	case "spec.owner":
+		e.Spec.Owner = value
+	case "spec.lifecycle":
+		e.Spec.Lifecycle = value
+	case "spec.system":
+		e.Spec.System = value
+	case "spec.type":
+		e.Spec.Type = value
+	default:
+		return fmt.Errorf("unsupported backstage field: %s", field)
+	}
+	return nil
+}
+
+// backstageDependencies maps the dependency paths of a module to the
+// entity references of the modules containing these paths. Dependencies
+// which are not part of a module (i.e. go.mod) are skipped.
+func (i *Inventory) backstageDependencies(module *modules.KaeterModule) []string {
+	var dependsOn []string
+	for _, dependency := range module.Dependencies {
+		dependencyModule := i.FindModuleContainingPath(dependency)
+		if dependencyModule == nil || dependencyModule.ModuleID == module.ModuleID {
+			continue
+		}
+		ref := "component:" + backstageEntityName(dependencyModule.ModuleID)
+		if !slices.Contains(dependsOn, ref) {
+			dependsOn = append(dependsOn, ref)
+		}
+	}
+	return dependsOn
+}
+
+// FindModuleContainingPath returns the module with the longest path containing the given path
+// relative to the repository root, or nil if no module contains it.
+func (i *Inventory) FindModuleContainingPath(path string) *modules.KaeterModule {
+	var match *modules.KaeterModule
+	for idx := range i.ModuleInventory.Modules {
+		candidate := &i.ModuleInventory.Modules[idx]
+		if !IsWithinPath(candidate.ModulePath, path) {
+			continue
+		}
+		if match == nil || len(filepath.Clean(candidate.ModulePath)) > len(filepath.Clean(match.ModulePath)) {
+			match = candidate
+		}
+	}
+	return match
+}
+
+// IsWithinPath returns whether path is parent itself or inside it, both relative to the repository root.
+func IsWithinPath(parent, path string) bool {
+	cleanParent := filepath.Clean(parent)
+	cleanPath := filepath.Clean(path)
+	return cleanParent == "." || cleanPath == cleanParent ||
+		strings.HasPrefix(cleanPath, cleanParent+string(filepath.Separator))
+}
+
+// backstageEntityName converts a module ID to a valid backstage entity name,
+// i.e. ch.open.kaeter:unit-test becomes ch.open.kaeter-unit-test
+func backstageEntityName(moduleID string) string {
+	name := backstageInvalidNameChars.ReplaceAllString(moduleID, "-")
+	if len(name) > backstageNameMaxLength {
+		name = name[:backstageNameMaxLength]
+	}
+	return strings.Trim(name, "-_.")
+}
@@ -0,0 +1,204 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInventory_ToBackstage(t *testing.T) {
+	var tests = []struct {
+		name     string
+		config   *BackstageConfig
+		expected string
+		mustFail bool
+	}{
+		{
+			name:   "defaults only",
+			config: &BackstageConfig{Owner: "team-a", Lifecycle: "production", Type: "service"},
+			expected: `---
+apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+  name: example.com-module1
+  title: example.com:module1
+  annotations:
+    open.ch/kaeter-module-id: example.com:module1
+    open.ch/kaeter-module-path: happiness/this/way
+spec:
+  type: service
+  lifecycle: production
+  owner: team-a
+---
+apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+  name: example.com-module2
+  title: example.com:module2
+  annotations:
+    open.ch/kaeter-module-id: example.com:module2
+    open.ch/kaeter-module-path: that/way
+spec:
+  type: service
+  lifecycle: production
+  owner: team-a
+  dependsOn:
+  - component:example.com-module3
+---
+apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+  name: example.com-module3
+  title: example.com:module3
+  annotations:
+    open.ch/kaeter-module-id: example.com:module3
+    open.ch/kaeter-module-path: this/is/the/way
+spec:
+  type: service
+  lifecycle: production
+  owner: team-a
+`,
+		},
+		{
+			name: "annotations mapped to fields",
+			config: &BackstageConfig{
+				Owner: "team-a",
+				Mappings: []BackstageMapping{
+					{Annotation: "example.com/annotation1", Field: "spec.owner"},
+					{Annotation: "example.com/annotation2", Field: "metadata.labels.enabled"},
+				},
+			},
+			expected: `---
+apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+  name: example.com-module1
+  title: example.com:module1
+  annotations:
+    open.ch/kaeter-module-id: example.com:module1
+    open.ch/kaeter-module-path: happiness/this/way
+spec:
+  type: ""
+  lifecycle: ""
+  owner: team-a
+---
+apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+  name: example.com-module2
+  title: example.com:module2
+  annotations:
+    open.ch/kaeter-module-id: example.com:module2
+    open.ch/kaeter-module-path: that/way
+spec:
+  type: ""
+  lifecycle: ""
+  owner: annotation1
+  dependsOn:
+  - component:example.com-module3
+---
+apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+  name: example.com-module3
+  title: example.com:module3
+  annotations:
+    open.ch/kaeter-module-id: example.com:module3
+    open.ch/kaeter-module-path: this/is/the/way
+  labels:
+    enabled: "yes"
+spec:
+  type: ""
+  lifecycle: ""
+  owner: "no"
+`,
+		},
+		{
+			name: "unsupported field fails",
+			config: &BackstageConfig{
+				Mappings: []BackstageMapping{
+					{Annotation: "example.com/annotation1", Field: "spec.unicorn"},
+				},
+			},
+			mustFail: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			inv := mockInventoryObject(t)
+			inv.ModuleInventory.Modules[1].Dependencies = []string{"this/is/the/way/lib", "go.mod"}
+
+			got, err := inv.ToBackstage(tc.config)
+
+			if tc.mustFail {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestInventory_ToBackstageOwner(t *testing.T) {
+	var tests = []struct {
+		name          string
+		owners        []string
+		ownerMapping  bool
+		expectedOwner string
+	}{
+		{
+			name:          "default owner without CODEOWNERS",
+			expectedOwner: "team-a",
+		},
+		{
+			name:          "first owner from CODEOWNERS",
+			owners:        []string{"@team-b", "@team-c"},
+			expectedOwner: "team-b",
+		},
+		{
+			name:          "mapping takes precedence over CODEOWNERS",
+			owners:        []string{"@team-b"},
+			ownerMapping:  true,
+			expectedOwner: "annotation1",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			inv := mockInventoryObject(t)
+			module := &inv.ModuleInventory.Modules[1]
+			module.Owners = tc.owners
+			config := &BackstageConfig{Owner: "team-a"}
+			if tc.ownerMapping {
+				config.Mappings = []BackstageMapping{{Annotation: "example.com/annotation1", Field: "spec.owner"}}
+			}
+
+			entity, err := inv.toBackstageEntity(module, config)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOwner, entity.Spec.Owner)
+		})
+	}
+}
+
+func TestBackstageEntityName(t *testing.T) {
+	var tests = []struct {
+		name     string
+		moduleID string
+		expected string
+	}{
+		{name: "colon replaced", moduleID: "ch.open.kaeter:unit-test", expected: "ch.open.kaeter-unit-test"},
+		{name: "already valid", moduleID: "my_module.v2", expected: "my_module.v2"},
+		{name: "leading separators trimmed", moduleID: ":module:", expected: "module"},
+		{
+			name:     "truncated to 63 characters",
+			moduleID: "a123456789b123456789c123456789d123456789e123456789f123456789g123456789",
+			expected: "a123456789b123456789c123456789d123456789e123456789f123456789g12",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, backstageEntityName(tc.moduleID))
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/open-ch/kaeter/modules"
+)
+
+// Supported filter operators, the order matters since a clause is split on the
+// first operator found and != contains =.
+const (
+	opNotEqual = "!="
+	opPrefix   = "^="
+	opEqual    = "="
+	opIsSet    = ""
+)
+
+const clauseSeparator = "&&"
+
+// Filter holds a parsed inventory query expression, a module matches
+// the filter when all clauses of the expression match.
+type Filter struct {
+	clauses []filterClause
+}
+
+type filterClause struct {
+	field      string
+	annotation string // only set for annotation[key] fields
+	operator   string
+	value      string
+}
+
+// ParseFilter parses a filter expression made of clauses joined by &&, for example:
+//
+//	annotation[team]=payments && type=Makefile && path^=services/
+//
+// Supported fields are id, path, type, tag, dependency, autorelease and annotation[key].
+// Supported operators are = (equal), != (not equal) and ^= (prefix), a field without
+// operator matches modules where the field is set (i.e. `autorelease` for pending autoreleases).
+func ParseFilter(expression string) (*Filter, error) {
+	filter := &Filter{}
+	if strings.TrimSpace(expression) == "" {
+		return filter, nil
+	}
+
+	for rawClause := range strings.SplitSeq(expression, clauseSeparator) {
+		clause, err := parseFilterClause(strings.TrimSpace(rawClause))
+		if err != nil {
+			return nil, err
+		}
+		filter.clauses = append(filter.clauses, clause)
+	}
+	return filter, nil
+}
+
+func parseFilterClause(rawClause string) (filterClause, error) {
+	if rawClause == "" {
+		return filterClause{}, errors.New("empty clause in filter expression")
+	}
+
+	clause := filterClause{field: rawClause, operator: opIsSet}
+	// The field ends at the first operator, the value may contain operators (i.e. annotation[x]=a!=b)
+	for i := range rawClause {
+		operator, found := operatorAt(rawClause[i:])
+		if found {
+			clause = filterClause{
+				field:    strings.TrimSpace(rawClause[:i]),
+				operator: operator,
+				value:    strings.TrimSpace(rawClause[i+len(operator):]),
+			}
+			break
+		}
+	}
+
+	if annotation, found := strings.CutPrefix(clause.field, "annotation["); found {
+		if !strings.HasSuffix(annotation, "]") || len(annotation) < 2 {
+			return filterClause{}, fmt.Errorf("invalid annotation field in filter clause: %s", rawClause)
+		}
+		clause.annotation = strings.TrimSuffix(annotation, "]")
+		clause.field = "annotation"
+	}
+
+	switch clause.field {
+	case "id", "path", "type", "tag", "dependency", "autorelease", "annotation":
+	default:
+		return filterClause{}, fmt.Errorf("unknown field '%s' in filter clause: %s", clause.field, rawClause)
+	}
+	return clause, nil
+}
+
+// operatorAt returns the operator at the start of the string if any
+func operatorAt(s string) (string, bool) {
+	for _, operator := range []string{opNotEqual, opPrefix, opEqual} {
+		if strings.HasPrefix(s, operator) {
+			return operator, true
+		}
+	}
+	return "", false
+}
+
+// Matches returns true if the module satisfies all clauses of the filter,
+// an empty filter matches every module.
+func (f *Filter) Matches(module *modules.KaeterModule) bool {
+	for _, clause := range f.clauses {
+		if !clause.matches(module) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *filterClause) matches(module *modules.KaeterModule) bool {
+	switch c.field {
+	case "id":
+		return c.compare(module.ModuleID)
+	case "path":
+		return c.compare(module.ModulePath)
+	case "type":
+		return c.compare(module.ModuleType)
+	case "autorelease":
+		return c.compare(module.AutoRelease)
+	case "annotation":
+		return c.compare(module.Annotations[c.annotation])
+	case "tag":
+		return c.compareAny(module.Tags)
+	case "dependency":
+		return c.compareAny(module.Dependencies)
+	}
+	return false
+}
+
+func (c *filterClause) compare(actual string) bool {
+	switch c.operator {
+	case opEqual:
+		return actual == c.value
+	case opNotEqual:
+		return actual != c.value
+	case opPrefix:
+		return strings.HasPrefix(actual, c.value)
+	default:
+		return actual != ""
+	}
+}
+
+// compareAny handles list fields: = and ^= match if any of the values match
+// while != only matches if none of the values are equal.
+func (c *filterClause) compareAny(values []string) bool {
+	if c.operator == opNotEqual {
+		return !slices.Contains(values, c.value)
+	}
+	return slices.ContainsFunc(values, c.compare)
+}
+
+// Filter returns a new inventory containing only the modules matching the filter.
+func (i *Inventory) Filter(filter *Filter) *Inventory {
+	lookup := make(map[string]modules.KaeterModule)
+	var matching []modules.KaeterModule
+
+	for _, m := range i.ModuleInventory.Modules {
+		if filter.Matches(&m) {
+			lookup[m.ModuleID] = m
+			matching = append(matching, m)
+		}
+	}
+
+	return &Inventory{
+		Lookup: lookup,
+		ModuleInventory: ModuleInventory{
+			Modules:  matching,
+			RepoRoot: i.ModuleInventory.RepoRoot,
+		},
+	}
+}
+
+// ModuleIDs returns the IDs of all modules in the inventory in order.
+func (i *Inventory) ModuleIDs() []string {
+	ids := make([]string, 0, len(i.ModuleInventory.Modules))
+	for _, m := range i.ModuleInventory.Modules {
+		ids = append(ids, m.ModuleID)
+	}
+	return ids
+}
+
+// ModulePaths returns the paths of all modules in the inventory in order.
+func (i *Inventory) ModulePaths() []string {
+	paths := make([]string, 0, len(i.ModuleInventory.Modules))
+	for _, m := range i.ModuleInventory.Modules {
+		paths = append(paths, m.ModulePath)
+	}
+	return paths
+}
@@ -0,0 +1,208 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFilter(t *testing.T) {
+	var tests = []struct {
+		name       string
+		expression string
+		clauses    int
+		mustFail   bool
+	}{
+		{
+			name:       "empty expression matches everything",
+			expression: "",
+			clauses:    0,
+		},
+		{
+			name:       "single clause",
+			expression: "type=Makefile",
+			clauses:    1,
+		},
+		{
+			name:       "multiple clauses with spaces",
+			expression: "annotation[team]=payments && type=Makefile && path^=services/",
+			clauses:    3,
+		},
+		{
+			name:       "clause without operator",
+			expression: "autorelease",
+			clauses:    1,
+		},
+		{
+			name:       "unknown field fails",
+			expression: "color=blue",
+			mustFail:   true,
+		},
+		{
+			name:       "unterminated annotation fails",
+			expression: "annotation[team=payments",
+			mustFail:   true,
+		},
+		{
+			name:       "empty clause fails",
+			expression: "type=Makefile && ",
+			mustFail:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := ParseFilter(tc.expression)
+			if tc.mustFail {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, filter.clauses, tc.clauses)
+		})
+	}
+}
+
+func TestParseFilterClause(t *testing.T) {
+	var tests = []struct {
+		name     string
+		clause   string
+		expected filterClause
+	}{
+		{
+			name:     "equal",
+			clause:   "type = Makefile",
+			expected: filterClause{field: "type", operator: opEqual, value: "Makefile"},
+		},
+		{
+			name:     "not equal",
+			clause:   "type!=Makefile",
+			expected: filterClause{field: "type", operator: opNotEqual, value: "Makefile"},
+		},
+		{
+			name:     "prefix",
+			clause:   "path^=services/",
+			expected: filterClause{field: "path", operator: opPrefix, value: "services/"},
+		},
+		{
+			name:     "value containing operators",
+			clause:   "annotation[x]=a!=b^=c",
+			expected: filterClause{field: "annotation", annotation: "x", operator: opEqual, value: "a!=b^=c"},
+		},
+		{
+			name:     "not equal value containing equal",
+			clause:   "annotation[x]!=a=b",
+			expected: filterClause{field: "annotation", annotation: "x", operator: opNotEqual, value: "a=b"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			clause, err := parseFilterClause(tc.clause)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, clause)
+		})
+	}
+}
+
+func TestInventory_Filter(t *testing.T) {
+	var tests = []struct {
+		name       string
+		expression string
+		expected   []string
+	}{
+		{
+			name:       "empty filter keeps all modules",
+			expression: "",
+			expected:   []string{"example.com:module1", "example.com:module2", "example.com:module3"},
+		},
+		{
+			name:       "by id",
+			expression: "id=example.com:module2",
+			expected:   []string{"example.com:module2"},
+		},
+		{
+			name:       "by path prefix",
+			expression: "path^=th",
+			expected:   []string{"example.com:module2", "example.com:module3"},
+		},
+		{
+			name:       "by annotation value",
+			expression: "annotation[example.com/annotation1]=no",
+			expected:   []string{"example.com:module3"},
+		},
+		{
+			name:       "by annotation presence",
+			expression: "annotation[example.com/annotation1]",
+			expected:   []string{"example.com:module2", "example.com:module3"},
+		},
+		{
+			name:       "by annotation absence",
+			expression: "annotation[example.com/annotation1]=",
+			expected:   []string{"example.com:module1"},
+		},
+		{
+			name:       "pending autorelease",
+			expression: "autorelease",
+			expected:   []string{"example.com:module3"},
+		},
+		{
+			name:       "combined clauses",
+			expression: "type=nice && annotation[example.com/annotation1]!=no",
+			expected:   []string{"example.com:module1", "example.com:module2"},
+		},
+		{
+			name:       "no match",
+			expression: "type=Makefile",
+			expected:   []string{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := ParseFilter(tc.expression)
+			assert.NoError(t, err)
+
+			filtered := mockInventoryObject(t).Filter(filter)
+
+			assert.Equal(t, tc.expected, filtered.ModuleIDs())
+			assert.Len(t, filtered.Lookup, len(tc.expected))
+		})
+	}
+}
+
+func TestInventory_FilterListFields(t *testing.T) {
+	inv := mockInventoryObject(t)
+	inv.ModuleInventory.Modules[0].Tags = []string{"lts", "stable"}
+	inv.ModuleInventory.Modules[1].Dependencies = []string{"go.mod", "lib/shared"}
+
+	var tests = []struct {
+		name       string
+		expression string
+		expected   []string
+	}{
+		{
+			name:       "tag equal",
+			expression: "tag=lts",
+			expected:   []string{"example.com:module1"},
+		},
+		{
+			name:       "tag not equal",
+			expression: "tag!=lts",
+			expected:   []string{"example.com:module2", "example.com:module3"},
+		},
+		{
+			name:       "dependency prefix",
+			expression: "dependency^=lib/",
+			expected:   []string{"example.com:module2"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := ParseFilter(tc.expression)
+			assert.NoError(t, err)
+
+			filtered := inv.Filter(filter)
+
+			assert.Equal(t, tc.expected, filtered.ModuleIDs())
+		})
+	}
+}
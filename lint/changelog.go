@@ -1,15 +1,66 @@
 package lint
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 	"time"
 
+	"github.com/spf13/viper"
+
 	"github.com/open-ch/kaeter/modules"
 )
 
-const changelogEntryRegex = `## ([^\s]+) - ([0-9][0-9]?\.[0-9][0-9]?\.[0-9][0-9])(?:\s.*)?`
+// Formats of the entry headers of markdown changelogs, selected with changelog.format in the kaeter config
+const (
+	// ChangelogFormatAuto accepts the headers of both formats (default)
+	ChangelogFormatAuto = "auto"
+	// ChangelogFormatKaeter headers are: ## 1.2.3 - 26.05.20 (or an ISO date) optionally followed by the authors
+	ChangelogFormatKaeter = "kaeter"
+	// ChangelogFormatKeepAChangelog headers are: ## [1.2.3] - 2020-05-26 with an optional ## [Unreleased] section
+	// at the top, see https://keepachangelog.com
+	ChangelogFormatKeepAChangelog = "keepachangelog"
+)
+
+const changelogFormatViperKey = "changelog.format"
+
+// changelogDateToleranceViperKey configures how far the date of a changelog entry can be from the release date
+const changelogDateToleranceViperKey = "changelog.datetolerance"
+
+// defaultChangelogDateTolerance allows for the time between preparing a release and merging it
+const defaultChangelogDateTolerance = 7 * 24 * time.Hour
+
+// changelogDateRegex matches day.month.year dates (with 2 or 4 digits years) and ISO dates
+const changelogDateRegex = `[0-9][0-9]?\.[0-9][0-9]?\.[0-9]{2}(?:[0-9]{2})?|[0-9]{4}-[0-9]{2}-[0-9]{2}`
+
+// changelogEntryRegex matches the section titles of the kaeter format: [version, date]
+const changelogEntryRegex = `([^\s\[\]]+) - (` + changelogDateRegex + `)(?:[ \t].*)?`
+
+// keepAChangelogEntryRegex matches the section titles of the Keep a Changelog format: [version, date]
+const keepAChangelogEntryRegex = `\[([^\s\[\]]+)\] - ([0-9]{4}-[0-9]{2}-[0-9]{2})(?:[ \t].*)?`
+
+// rstUnderlineRegex matches the line of punctuation under reStructuredText section titles
+const rstUnderlineRegex = `[-=~^"'#*+]{3,}[ \t]*`
+
+// markupSyntax describes the section titles of the entries of a changelog written in a markup language,
+// the titles themselves use the kaeter or Keep a Changelog format.
+type markupSyntax struct {
+	sectionPrefix string // Before the title, i.e. "## " for markdown
+	underlined    bool   // reStructuredText titles are followed by a line of punctuation instead
+}
+
+//nolint:gochecknoglobals
+var (
+	markdownSyntax = markupSyntax{sectionPrefix: "## "}
+	asciidocSyntax = markupSyntax{sectionPrefix: "== "}
+	rstSyntax      = markupSyntax{underlined: true}
+)
+
+// linkReferenceRegex matches markdown link reference definitions, i.e. the version links of Keep a Changelog:
+// [1.2.3]: https://example.com/compare/v1.2.2...v1.2.3
+var linkReferenceRegex = regexp.MustCompile(`(?m)^\[[^\]]+\]:[ \t]+\S.*(?:\n|$)`) //nolint:gochecknoglobals
 
 // Changelog is a struct that represents a changelog file
 type Changelog struct {
@@ -20,7 +71,8 @@ type Changelog struct {
 type ChangelogEntry struct {
 	Version   modules.VersionIdentifier
 	Content   *ChangelogEntryContent
-	Timestamp *time.Time // TODO: agree on the time format (dd.mm.yy for now)
+	Timestamp *time.Time // dd.mm.yy or ISO date for the kaeter format, ISO date for Keep a Changelog
+	Line      int        // Line of the entry header in the changelog, starting at 1
 }
 
 // ChangelogEntryContent is a struct that represents the content of a changelog entry
@@ -31,22 +83,22 @@ type ChangelogEntryContent struct {
 
 // UnmarshalTimestampString builds a Timestamp struct from a changelog line
 func UnmarshalTimestampString(changelogLine string) (*time.Time, error) {
+	_, date, err := parseEntryHeader(changelogEntryHeaderRegex(ChangelogFormatAuto), changelogLine)
+	if err != nil {
+		return nil, err
+	}
+	return parseChangelogDate(date)
+}
+
+// parseChangelogDate parses the date of a kaeter or Keep a Changelog entry header
+func parseChangelogDate(date string) (*time.Time, error) {
 	// multiple date formats are supported using golang layouts: https://golang.org/src/time/format.go
 	dateFormats := []string{
 		"2.1.06", "2.1.2006", "2.01.06", "2.01.2006",
 		"02.1.06", "02.1.2006", "02.01.06", "02.01.2006",
+		time.DateOnly,
 	}
 
-	re := regexp.MustCompile(changelogEntryRegex)
-	// [line, versionCaptureGroup, dateCapturegroup]
-	versionComponents := re.FindStringSubmatch(changelogLine)
-	if versionComponents == nil || len(versionComponents) != 3 {
-		return nil, fmt.Errorf("unable to parse changelog entry %s", changelogLine)
-	}
-
-	date := versionComponents[2]
-	var err error
-
 	for _, dateFormat := range dateFormats {
 		timestamp, err := time.Parse(dateFormat, date)
 		if err == nil {
@@ -54,11 +106,86 @@ func UnmarshalTimestampString(changelogLine string) (*time.Time, error) {
 		}
 	}
 
-	return nil, err
+	return nil, fmt.Errorf("unable to parse date %s of changelog entry", date)
+}
+
+// changelogFormat returns the format of markdown changelogs from the kaeter config
+func changelogFormat() (string, error) {
+	switch format := viper.GetString(changelogFormatViperKey); format {
+	case "":
+		return ChangelogFormatAuto, nil
+	case ChangelogFormatAuto, ChangelogFormatKaeter, ChangelogFormatKeepAChangelog:
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid %s '%s' in the config, supported: %s, %s or %s", changelogFormatViperKey, format,
+			ChangelogFormatAuto, ChangelogFormatKaeter, ChangelogFormatKeepAChangelog)
+	}
+}
+
+// changelogEntryHeaderRegex returns the regex matching the markdown entry headers of the format
+func changelogEntryHeaderRegex(format string) *regexp.Regexp {
+	return markdownSyntax.entryHeaderRegex(format)
+}
+
+// sectionRegex returns the regex matching the sections whose title matches the given regex,
+// the lines may end with \r\n (CRLF).
+func (syntax markupSyntax) sectionRegex(title string) *regexp.Regexp {
+	if syntax.underlined {
+		return regexp.MustCompile(`(?m)^(?:` + title + `)\r?\n` + rstUnderlineRegex + `\r?$`)
+	}
+	return regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(syntax.sectionPrefix) + `(?:` + title + `)\r?$`)
+}
+
+// entryHeaderRegex returns the regex matching the entry headers of the format, each alternative
+// has 2 capture groups: the version and the date.
+func (syntax markupSyntax) entryHeaderRegex(format string) *regexp.Regexp {
+	switch format {
+	case ChangelogFormatKaeter:
+		return syntax.sectionRegex(changelogEntryRegex)
+	case ChangelogFormatKeepAChangelog:
+		return syntax.sectionRegex(keepAChangelogEntryRegex)
+	default:
+		return syntax.sectionRegex(changelogEntryRegex + `|` + keepAChangelogEntryRegex)
+	}
+}
+
+// unreleasedRegex matches the Keep a Changelog header of the changes not released yet
+func (syntax markupSyntax) unreleasedRegex() *regexp.Regexp {
+	return syntax.sectionRegex(`\[Unreleased\][ \t]*`)
+}
+
+// anySectionRegex matches any section at the level of the entries
+func (syntax markupSyntax) anySectionRegex() *regexp.Regexp {
+	return syntax.sectionRegex(`\S.*`)
+}
+
+// parseEntryHeader returns the version and date of a changelog entry header
+func parseEntryHeader(headerRegex *regexp.Regexp, changelogLine string) (version, date string, err error) {
+	match := headerRegex.FindStringSubmatch(changelogLine)
+	for i := 1; i+1 < len(match); i += 2 {
+		if match[i] != "" {
+			return match[i], match[i+1], nil
+		}
+	}
+	return "", "", fmt.Errorf("unable to parse changelog entry %s", changelogLine)
+}
+
+// detectFormat returns the format of the changelog from its entries, kaeter unless it uses Keep a Changelog
+func (syntax markupSyntax) detectFormat(changelog string) string {
+	keepAChangelogHeader := syntax.entryHeaderRegex(ChangelogFormatKeepAChangelog)
+	if syntax.unreleasedRegex().MatchString(changelog) || keepAChangelogHeader.MatchString(changelog) {
+		return ChangelogFormatKeepAChangelog
+	}
+	return ChangelogFormatKaeter
 }
 
 func checkMarkdownChangelog(changelogPath string, versions *modules.Versions) error {
-	changelog, err := readMarkdownChangelog(changelogPath)
+	return checkChangelogEntries(changelogPath, versions, UnmarshalChangelog)
+}
+
+// checkChangelogEntries verifies that every released version has an entry in the changelog
+func checkChangelogEntries(changelogPath string, versions *modules.Versions, unmarshal func(string) (*Changelog, error)) error {
+	changelog, err := readChangelog(changelogPath, unmarshal)
 	if err != nil {
 		return fmt.Errorf("error in parsing %s: %s", changelogPath, err.Error())
 	}
@@ -80,17 +207,120 @@ func checkMarkdownChangelog(changelogPath string, versions *modules.Versions) er
 	return nil
 }
 
+// checkChangelogEntryDates verifies that the entries of the released versions are dated around their release date,
+// that the entries are in descending version order and that no version is listed twice.
+func checkChangelogEntryDates(changelogPath string, versions *modules.Versions, unmarshal func(string) (*Changelog, error)) error {
+	changelog, err := readChangelog(changelogPath, unmarshal)
+	if err != nil {
+		return fmt.Errorf("error in parsing %s: %s", changelogPath, err.Error())
+	}
+	dateTolerance, err := changelogDateTolerance()
+	if err != nil {
+		return err
+	}
+
+	changelogEntries := make(map[string]ChangelogEntry)
+	var changelogErrors error
+	for _, entry := range changelog.Entries {
+		version := entry.Version.String()
+		if _, duplicate := changelogEntries[version]; duplicate {
+			changelogErrors = errors.Join(changelogErrors, &locatedError{
+				file: changelogPath,
+				line: entry.Line,
+				err:  fmt.Errorf("version '%s' has more than one entry in '%s'", version, changelogPath),
+			})
+			continue
+		}
+		changelogEntries[version] = entry
+	}
+
+	for _, releasedVersion := range versions.ReleasedVersions {
+		entry, exists := changelogEntries[releasedVersion.Number.String()]
+		if !exists {
+			continue // Missing entries are reported by checkChangelogEntries
+		}
+		if releasedVersion.CommitID == modules.InitRef || releasedVersion.CommitID == autoReleaseHash {
+			continue // Placeholder timestamps, autorelease ones are bumped until the release is made
+		}
+		if !datesWithinTolerance(*entry.Timestamp, releasedVersion.Timestamp, dateTolerance) {
+			changelogErrors = errors.Join(changelogErrors, &locatedError{
+				file: changelogPath,
+				line: entry.Line,
+				err: fmt.Errorf("date %s of version '%s' in '%s' does not match its release date %s in versions.yaml",
+					entry.Timestamp.Format(time.DateOnly), releasedVersion.Number.String(), changelogPath, releasedVersion.Timestamp.Format(time.DateOnly)),
+			})
+		}
+	}
+
+	return errors.Join(changelogErrors, checkChangelogOrder(changelogPath, changelog, versions))
+}
+
+// checkChangelogOrder verifies that the entries are listed from the latest to the oldest version, comparing the
+// parsed version numbers. Arbitrary string versions have no order, nor do entries whose version does not parse.
+func checkChangelogOrder(changelogPath string, changelog *Changelog, versions *modules.Versions) error {
+	if strings.EqualFold(versions.VersioningType, modules.AnyStringVer) {
+		return nil
+	}
+
+	var previousVersion *modules.VersionNumber
+	for _, entry := range changelog.Entries {
+		parsedVersion, err := modules.UnmarshalVersionString(entry.Version.String(), versions.VersioningType)
+		if err != nil {
+			continue
+		}
+		version, ok := parsedVersion.(*modules.VersionNumber)
+		if !ok {
+			continue
+		}
+		if previousVersion != nil && !version.LessThan(&previousVersion.Version) {
+			return &locatedError{
+				file: changelogPath,
+				line: entry.Line,
+				err: fmt.Errorf("version '%s' is listed after '%s' in '%s', entries must be in descending version order",
+					entry.Version.String(), previousVersion.String(), changelogPath),
+			}
+		}
+		previousVersion = version
+	}
+	return nil
+}
+
+// changelogDateTolerance returns the maximum difference allowed between the date of a changelog entry
+// and the release date in versions.yaml from the kaeter config
+func changelogDateTolerance() (time.Duration, error) {
+	if !viper.IsSet(changelogDateToleranceViperKey) {
+		return defaultChangelogDateTolerance, nil
+	}
+	tolerance, err := time.ParseDuration(viper.GetString(changelogDateToleranceViperKey))
+	if err != nil || tolerance < 0 {
+		return 0, fmt.Errorf("invalid %s '%s' in the config, expected a positive duration (i.e. 72h)",
+			changelogDateToleranceViperKey, viper.GetString(changelogDateToleranceViperKey))
+	}
+	return tolerance, nil
+}
+
+// datesWithinTolerance compares the days (UTC) of the changelog date and of the release timestamp,
+// most changelogs only have the day of the release.
+func datesWithinTolerance(changelogDate, releaseTimestamp time.Time, tolerance time.Duration) bool {
+	releaseDate := releaseTimestamp.UTC().Truncate(24 * time.Hour)
+	difference := changelogDate.UTC().Truncate(24 * time.Hour).Sub(releaseDate).Abs()
+	return difference <= tolerance
+}
+
 // Parses released changelog entries as a tuple of version and date
 // Where the supported line format is:
-// - an h2 (##) for each release
-// - First a version number SemVer or AnyStringVer
+// - a section for each release (h2 ## in markdown, == in AsciiDoc, underlined title in reStructuredText)
+// - First a version number SemVer or AnyStringVer, in brackets for Keep a Changelog
 // - dash surround ded by spaces
-// - release date (dd.mm.yy)
+// - release date (dd.mm.yy or ISO yyyy-mm-dd, only ISO for Keep a Changelog)
 // - additional information (authors, ...)
-func getEntries(str string) ([]ChangelogEntry, error) {
-	re := regexp.MustCompile(changelogEntryRegex)
+// Anything before the first release, i.e. the Keep a Changelog [Unreleased] section, is ignored
+// as well as link reference definitions.
+func getEntries(str string, syntax markupSyntax, format string) ([]ChangelogEntry, error) {
+	re := syntax.entryHeaderRegex(format)
 	// Grabs only the matching header lines
 	changelogEntryHeaders := re.FindAllString(str, -1)
+	headerLocations := re.FindAllStringIndex(str, -1)
 	// Splits the changelog into blocks which include the release notes
 	// The first block will include what comes before the first release (main title and ...)
 	changeLogSplitContent := re.Split(str, -1)
@@ -100,11 +330,16 @@ func getEntries(str string) ([]ChangelogEntry, error) {
 	// ...hopefully
 	for i, content := range changeLogSplitContent[1:] {
 		change := changelogEntryHeaders[i]
-		versionNumber, err := extractVersionString(change)
+		versionStr, date, err := parseEntryHeader(re, change)
+		if err != nil {
+			return nil, err
+		}
+		versionNumber, err := modules.UnmarshalVersionString(versionStr, modules.AnyStringVer)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing release version number: %w", err)
 		}
-		timestamp, err := UnmarshalTimestampString(change)
+		content = linkReferenceRegex.ReplaceAllString(content, "")
+		timestamp, err := parseChangelogDate(date)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing release timestamp: %w", err)
 		}
@@ -113,6 +348,7 @@ func getEntries(str string) ([]ChangelogEntry, error) {
 			Version:   versionNumber,
 			Content:   &ChangelogEntryContent{content},
 			Timestamp: timestamp,
+			Line:      lineAt(str, headerLocations[i][0]),
 		}
 		entries[i] = entry
 	}
@@ -121,8 +357,18 @@ func getEntries(str string) ([]ChangelogEntry, error) {
 }
 
 // UnmarshalChangelog builds a Changelog struct from a string containing a raw changelog file
+// using the entry header format from the kaeter config.
 func UnmarshalChangelog(changelog string) (*Changelog, error) {
-	entries, err := getEntries(changelog)
+	return markdownSyntax.unmarshalChangelog(changelog)
+}
+
+// unmarshalChangelog builds a Changelog struct from a changelog written with the markup syntax
+func (syntax markupSyntax) unmarshalChangelog(changelog string) (*Changelog, error) {
+	format, err := changelogFormat()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := getEntries(changelog, syntax, format)
 	if err != nil {
 		return nil, fmt.Errorf("error while parsing the changelog file: %s", err.Error())
 	}
@@ -132,22 +378,29 @@ func UnmarshalChangelog(changelog string) (*Changelog, error) {
 	}, nil
 }
 
+// lineAt returns the line number (starting at 1) of the byte offset in the text
+func lineAt(text string, offset int) int {
+	return strings.Count(text[:offset], "\n") + 1
+}
+
 // readMarkdownChangelog reads a Changelog object from the file living at the passed path.
 func readMarkdownChangelog(path string) (*Changelog, error) {
+	return readChangelog(path, UnmarshalChangelog)
+}
+
+// readChangelog reads a Changelog object from the file living at the passed path using the given parser.
+func readChangelog(path string, unmarshal func(string) (*Changelog, error)) (*Changelog, error) {
 	bytes, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-	return UnmarshalChangelog(string(bytes))
+	return unmarshal(string(bytes))
 }
 
 func extractVersionString(changelogLine string) (modules.VersionIdentifier, error) {
-	re := regexp.MustCompile(changelogEntryRegex)
-	// [line, versionCaptureGroup, dateCapturegroup]
-	versionComponents := re.FindStringSubmatch(changelogLine)
-	if versionComponents == nil || len(versionComponents) != 3 {
-		return nil, fmt.Errorf("unable to parse changelog entry %s", changelogLine)
+	versionStr, _, err := parseEntryHeader(changelogEntryHeaderRegex(ChangelogFormatAuto), changelogLine)
+	if err != nil {
+		return nil, err
 	}
-	versionStr := versionComponents[1]
 	return modules.UnmarshalVersionString(versionStr, modules.AnyStringVer)
 }
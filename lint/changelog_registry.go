@@ -0,0 +1,129 @@
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-ch/kaeter/modules"
+)
+
+const changelogAsciiDocFile = "CHANGELOG.adoc"
+const changelogRSTFile = "CHANGELOG.rst"
+
+// changelogParser handles one kind of changelog: lint checks, release notes and new entries
+type changelogParser struct {
+	// name of the changelog for messages, i.e. CHANGELOG.md
+	name string
+	// find returns the path of the changelog relative to the module, an error if the module has none
+	find func(absModulePath string) (string, error)
+	// check verifies that the released versions have an entry in the changelog
+	check func(changelogPath string, versions *modules.Versions) error
+	// checkDates verifies the dates and order of the entries, nil if the changelog doesn't support it
+	checkDates func(changelogPath string, versions *modules.Versions) error
+	// extractNotes returns the notes of the version, found is false if the changelog has no entry for it
+	extractNotes func(changelogRaw, version string) (notes string, found bool, err error)
+	// addEntry returns the changelog with the new entry
+	addEntry func(changelogRaw string, entry *NewChangelogEntry) (string, error)
+}
+
+// changelogParsers is the registry of the supported changelogs in lookup order, the first one found
+// in a module is its changelog.
+//
+//nolint:gochecknoglobals
+var changelogParsers = []changelogParser{
+	{
+		name:         changelogCHANGESFile,
+		find:         changelogFile(changelogCHANGESFile),
+		check:        validateCHANGESFile,
+		extractNotes: extractCHANGESNotes,
+		addEntry: func(changes string, entry *NewChangelogEntry) (string, error) {
+			return appendCHANGESEntry(changes, entry), nil
+		},
+	},
+	markupChangelogParser(changelogMDFile, markdownSyntax),
+	markupChangelogParser(changelogAsciiDocFile, asciidocSyntax),
+	markupChangelogParser(changelogRSTFile, rstSyntax),
+	{
+		name: changelogDebianFile,
+		find: changelogFile(changelogDebianFile),
+		check: func(changelogPath string, versions *modules.Versions) error {
+			return checkChangelogEntries(changelogPath, versions, unmarshalDebianChangelog)
+		},
+		checkDates: func(changelogPath string, versions *modules.Versions) error {
+			return checkChangelogEntryDates(changelogPath, versions, unmarshalDebianChangelog)
+		},
+		extractNotes: entryNotesExtractor(unmarshalDebianChangelog),
+		addEntry:     insertDebianEntry,
+	},
+	{
+		name:         ".spec",
+		find:         findSpecFile,
+		check:        checkSpecChangelog,
+		extractNotes: extractSpecNotes,
+		addEntry:     insertSpecEntry,
+	},
+}
+
+// markupChangelogParser handles changelogs with a section per entry using the kaeter or Keep a Changelog titles
+func markupChangelogParser(fileName string, syntax markupSyntax) changelogParser {
+	return changelogParser{
+		name: fileName,
+		find: changelogFile(fileName),
+		check: func(changelogPath string, versions *modules.Versions) error {
+			return checkChangelogEntries(changelogPath, versions, syntax.unmarshalChangelog)
+		},
+		checkDates: func(changelogPath string, versions *modules.Versions) error {
+			return checkChangelogEntryDates(changelogPath, versions, syntax.unmarshalChangelog)
+		},
+		extractNotes: entryNotesExtractor(syntax.unmarshalChangelog),
+		addEntry: func(changelog string, entry *NewChangelogEntry) (string, error) {
+			return insertMarkupEntry(changelog, entry, syntax)
+		},
+	}
+}
+
+// changelogFile returns a finder for changelogs with a fixed path in the module
+func changelogFile(fileName string) func(absModulePath string) (string, error) {
+	return func(absModulePath string) (string, error) {
+		if err := checkExistence(fileName, absModulePath); err != nil {
+			return "", err
+		}
+		return fileName, nil
+	}
+}
+
+// entryNotesExtractor returns the extraction of the notes of a version for changelogs parsed into entries
+func entryNotesExtractor(unmarshal func(string) (*Changelog, error)) func(changelogRaw, version string) (string, bool, error) {
+	return func(changelogRaw, version string) (string, bool, error) {
+		changelog, err := unmarshal(changelogRaw)
+		if err != nil {
+			return "", false, err
+		}
+		for _, entry := range changelog.Entries {
+			if entry.Version.String() == version {
+				return strings.TrimSpace(entry.Content.Content), true, nil
+			}
+		}
+		return "", false, nil
+	}
+}
+
+// findChangelog returns the path of the changelog of the module along with its parser
+func findChangelog(absModulePath string) (string, *changelogParser, error) {
+	for i := range changelogParsers {
+		if changelogFile, err := changelogParsers[i].find(absModulePath); err == nil {
+			return filepath.Join(absModulePath, changelogFile), &changelogParsers[i], nil
+		}
+	}
+	return "", nil, fmt.Errorf("no %s file found for the module at %s", supportedChangelogs(), absModulePath)
+}
+
+// supportedChangelogs lists the names of the supported changelogs for messages
+func supportedChangelogs() string {
+	names := make([]string, len(changelogParsers))
+	for i, parser := range changelogParsers {
+		names[i] = parser.name
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
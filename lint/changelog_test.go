@@ -6,6 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/spf13/viper"
+
+	"github.com/open-ch/kaeter/mocks"
 	"github.com/open-ch/kaeter/modules"
 
 	"github.com/stretchr/testify/assert"
@@ -47,6 +50,30 @@ const sampleChangelogAnyVer = `# CHANGELOG
 - Test release with a -1 in the version number.
 `
 
+const sampleKeepAChangelog = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+## [Unreleased]
+
+### Added
+- Not released yet
+
+## [1.2.0] - 2020-05-26
+
+### Fixed
+- MDR-597 Proper _working_ initial release
+
+## [1.1.0] - 2020-05-26 [YANKED]
+
+## [1.0.0] - 2020-05-18
+
+Initial Release: cli stub for interfacing with Hashicorp Vault
+
+[unreleased]: https://example.com/compare/v1.2.0...HEAD
+[1.2.0]: https://example.com/compare/v1.1.0...v1.2.0
+`
+
 func TestCheckMarkdownChangelog(t *testing.T) {
 	testDataPath, err := filepath.Abs(testDataFolder)
 	assert.NoError(t, err)
@@ -60,6 +87,96 @@ func TestCheckMarkdownChangelog(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCheckMarkdownChangelogEntries(t *testing.T) {
+	const versionsYAML = `id: ch.open.tools:kaeter-police-tests
+type: Makefile
+versioning: SemVer
+versions:
+    0.0.0: 1970-01-01T00:00:00Z|INIT
+    1.0.0: 2024-05-01T10:00:00Z|hash
+    1.1.0: 2024-06-01T23:30:00Z|hash
+    1.0.1: 2024-06-15T10:00:00Z|hash
+    1.2.0: 2024-07-01T00:00:00Z|AUTORELEASE
+`
+	tests := []struct {
+		name          string
+		changelog     string
+		dateTolerance string
+		expectedError string
+	}{
+		{
+			name:      "Passes with matching dates in descending order",
+			changelog: "## 1.1.0 - 01.06.24\n## 1.0.0 - 2024-05-01\n## 0.9.0 - 01.01.23\n",
+		},
+		{
+			name:      "Passes with dates within the default tolerance",
+			changelog: "## 1.1.0 - 05.06.24\n## 1.0.0 - 28.04.24\n",
+		},
+		{
+			name:      "Ignores the dates of the INIT and pending autorelease versions",
+			changelog: "## 1.2.0 - 01.09.24\n## 1.1.0 - 01.06.24\n## 1.0.0 - 01.05.24\n## 0.0.0 - 01.01.23\n",
+		},
+		{
+			name:          "Fails with date outside of the tolerance",
+			changelog:     "## 1.1.0 - 20.06.24\n## 1.0.0 - 01.05.24\n",
+			expectedError: "date 2024-06-20 of version '1.1.0'",
+		},
+		{
+			name:          "Fails with date outside of the configured tolerance",
+			changelog:     "## 1.1.0 - 02.06.24\n## 1.0.0 - 01.05.24\n",
+			dateTolerance: "0h",
+			expectedError: "does not match its release date 2024-06-01",
+		},
+		{
+			name:          "Fails with invalid tolerance",
+			changelog:     "## 1.1.0 - 01.06.24\n## 1.0.0 - 01.05.24\n",
+			dateTolerance: "a week",
+			expectedError: "invalid changelog.datetolerance",
+		},
+		{
+			name:          "Fails with entries in ascending order",
+			changelog:     "## 1.0.0 - 01.05.24\n## 1.1.0 - 01.06.24\n",
+			expectedError: "version '1.1.0' is listed after '1.0.0'",
+		},
+		{
+			name:      "Passes with entries in descending version order when released in another order",
+			changelog: "## 1.1.0 - 01.06.24\n## 1.0.1 - 15.06.24\n## 1.0.0 - 01.05.24\n",
+		},
+		{
+			name:          "Fails with entries of versions missing from versions.yaml in ascending order",
+			changelog:     "## 1.1.0 - 01.06.24\n## 0.9.0 - 01.01.23\n## 0.10.0 - 01.02.23\n",
+			expectedError: "version '0.10.0' is listed after '0.9.0'",
+		},
+		{
+			name:          "Fails with duplicate version headers",
+			changelog:     "## 1.1.0 - 01.06.24\n## 1.1.0 - 01.06.24\n## 1.0.0 - 01.05.24\n",
+			expectedError: "version '1.1.0' has more than one entry",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.dateTolerance != "" {
+				viper.Set(changelogDateToleranceViperKey, tt.dateTolerance)
+				t.Cleanup(viper.Reset)
+			}
+			modulePath := t.TempDir()
+			mocks.CreateMockFile(t, modulePath, "versions.yaml", versionsYAML)
+			mocks.CreateMockFile(t, modulePath, changelogMDFile, "# CHANGELOG\n\n"+tt.changelog)
+			versions, err := modules.ReadFromFile(filepath.Join(modulePath, "versions.yaml"))
+			assert.NoError(t, err)
+
+			err = checkChangelogEntryDates(filepath.Join(modulePath, changelogMDFile), versions, UnmarshalChangelog)
+
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedError)
+			}
+		})
+	}
+}
+
 //revive:disable
 func TestExtractVersionString(t *testing.T) {
 	//nolint:govet // we know struct literal uses unkeyed fields
@@ -159,6 +276,16 @@ func TestUnmarshalTimestampString(t *testing.T) {
 			changelogLine: "## 1.2.0 - 09.05.2020",
 			expectedDate:  time.Date(2020, time.Month(5), 9, 0, 0, 0, 0, time.UTC),
 		},
+		{
+			name:          "Date format parsing: ISO",
+			changelogLine: "## 1.2.0 - 2020-05-09",
+			expectedDate:  time.Date(2020, time.Month(5), 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "Date format parsing: Keep a Changelog",
+			changelogLine: "## [1.2.0] - 2020-05-09",
+			expectedDate:  time.Date(2020, time.Month(5), 9, 0, 0, 0, 0, time.UTC),
+		},
 		{
 			name:          "Parsing date after anystring ver",
 			changelogLine: "## something - 9.5.22",
@@ -267,6 +394,60 @@ func TestAnyVerDashNumber(t *testing.T) {
 	assertVersionMatchesSemVer(t, entry, "version2-3")
 }
 
+func TestUnmarshalKeepAChangelog(t *testing.T) {
+	changelog, err := UnmarshalChangelog(sampleKeepAChangelog)
+	assert.NoError(t, err)
+
+	entries := changelog.Entries
+	assert.Len(t, entries, 3, "the unreleased section is not an entry")
+
+	assertDateMatches(t, &entries[0], 26, 5, 2020)
+	assertVersionMatchesSemVer(t, &entries[0], "1.2.0")
+	assert.Equal(t, "\n\n### Fixed\n- MDR-597 Proper _working_ initial release\n\n", entries[0].Content.Content)
+
+	assertDateMatches(t, &entries[1], 26, 5, 2020)
+	assertVersionMatchesSemVer(t, &entries[1], "1.1.0")
+
+	assertDateMatches(t, &entries[2], 18, 5, 2020)
+	assertVersionMatchesSemVer(t, &entries[2], "1.0.0")
+	assert.NotContains(t, entries[2].Content.Content, "https://example.com", "link references are not part of the notes")
+}
+
+func TestUnmarshalChangelogFormat(t *testing.T) {
+	tests := []struct {
+		name            string
+		format          string
+		changelog       string
+		expectedEntries int
+		expectError     bool
+	}{
+		{name: "Auto accepts kaeter format", changelog: sampleChangelog, expectedEntries: 3},
+		{name: "Auto accepts Keep a Changelog", format: ChangelogFormatAuto, changelog: sampleKeepAChangelog, expectedEntries: 3},
+		{name: "Auto accepts mixed formats", changelog: "## [1.1.0] - 2020-05-26\n\n## 1.0.0 - 18.5.20\n", expectedEntries: 2},
+		{name: "Kaeter format accepts ISO dates", format: ChangelogFormatKaeter, changelog: "## 1.0.0 - 2020-05-18\n", expectedEntries: 1},
+		{name: "Kaeter format ignores Keep a Changelog", format: ChangelogFormatKaeter, changelog: sampleKeepAChangelog},
+		{name: "Keep a Changelog ignores kaeter format", format: ChangelogFormatKeepAChangelog, changelog: sampleChangelog},
+		{name: "Keep a Changelog requires ISO dates", format: ChangelogFormatKeepAChangelog, changelog: "## [1.0.0] - 18.5.20\n"},
+		{name: "Fails for unknown format", format: "asciidoc", changelog: sampleChangelog, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set(changelogFormatViperKey, tt.format)
+			t.Cleanup(viper.Reset)
+
+			changelog, err := UnmarshalChangelog(tt.changelog)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, changelog.Entries, tt.expectedEntries)
+		})
+	}
+}
+
 func assertDateMatches(t *testing.T, e *ChangelogEntry, day, month, year int) {
 	assert.NotNil(t, e.Timestamp)
 	assert.Equal(t, day, e.Timestamp.Day())
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/open-ch/kaeter/log"
 	"github.com/open-ch/kaeter/modules"
 )
 
@@ -18,77 +20,146 @@ const autoReleaseHash = "AUTORELEASE"
 type CheckConfig struct {
 	RepoRoot string
 	Strict   bool
+	// Paths relative to RepoRoot limit lint to the modules in them and the modules depending on
+	// these, all modules are linted if empty.
+	Paths []string
 }
 
 // CheckModulesStartingFrom recursively looks for modules (having versions.yaml) and
 // validates they have the required files.
 // If modules are successfully detected, returns joined error containing errors
-// found on all the detected modules.
+// found on all the detected modules, warnings are logged.
 func CheckModulesStartingFrom(config CheckConfig) error {
-	resultsChan := modules.StreamFoundIn(config.RepoRoot)
-	var errs error
+	findings, err := FindIssuesStartingFrom(config)
+	if err != nil {
+		return err
+	}
+	return reportFindings(findings)
+}
+
+// FindIssuesStartingFrom recursively looks for modules (having versions.yaml) and returns
+// the findings of the lint rules on all of them (or on the ones selected by config.Paths) sorted
+// by file, modules failing to load are reported as versions-valid findings.
+// Fails only if the lint configuration is invalid.
+func FindIssuesStartingFrom(config CheckConfig) ([]Finding, error) {
+	configuredSeverities, err := config.configuredSeverities()
+	if err != nil {
+		return nil, err
+	}
 
-	for result := range resultsChan {
+	findings := []Finding{}
+	for _, result := range config.findModules() {
 		if result.Err != nil {
-			errs = errors.Join(errs, result.Err)
-		} else {
-			moduleAbsPath := filepath.Join(config.RepoRoot, result.Module.ModulePath)
-			versions := result.Module.GetVersions()
-			errs = errors.Join(errs, config.checkModule(moduleAbsPath, versions))
+			findings = append(findings, newFindings(config.RepoRoot, RuleVersionsValid, SeverityError, "", result.ErrPath(), result.Err)...)
+			continue
 		}
+		findings = append(findings, config.checkModule(configuredSeverities, config.newModuleToCheck(result.Module))...)
+	}
+	sortFindings(findings)
+	return findings, nil
+}
+
+// findModules returns the modules of the repository selected by config.Paths along with the ones failing to load
+func (config *CheckConfig) findModules() []modules.FindResult {
+	var results []modules.FindResult
+	for result := range modules.StreamFoundIn(config.RepoRoot) {
+		results = append(results, result)
+	}
+	return newModuleSelection(config.RepoRoot, config.Paths).filter(results)
+}
+
+func (config *CheckConfig) newModuleToCheck(module *modules.KaeterModule) *moduleToCheck {
+	return &moduleToCheck{
+		repoRoot:      config.RepoRoot,
+		absModulePath: filepath.Join(config.RepoRoot, module.ModulePath),
+		versionsPath:  module.GetVersionsPath(),
+		versions:      module.GetVersions(),
 	}
-	return errs
 }
 
 // CheckModuleFromVersionsFile validates the kaeter module
 // from a versions.yaml file checking that the required
-// files are present.
+// files are present, warnings are logged.
 func CheckModuleFromVersionsFile(config CheckConfig, versionsPath string) error {
-	moduleAbsPath := filepath.Dir(versionsPath)
-	versions, err := checkForValidVersionsFile(config.RepoRoot, versionsPath)
+	findings, err := FindModuleIssues(config, versionsPath)
 	if err != nil {
 		return err
 	}
-
-	return config.checkModule(moduleAbsPath, versions)
+	return reportFindings(findings)
 }
 
-func (config *CheckConfig) checkModule(moduleAbsPath string, versions *modules.Versions) error {
-	var allErrors error
-
-	err := checkForValidREADME(moduleAbsPath)
-	allErrors = errors.Join(allErrors, err)
-
-	err = checkForValidChangelog(versions, moduleAbsPath)
-	allErrors = errors.Join(allErrors, err)
+// FindModuleIssues returns the findings of the lint rules on the module of the versions.yaml file
+func FindModuleIssues(config CheckConfig, versionsPath string) ([]Finding, error) {
+	configuredSeverities, err := config.configuredSeverities()
+	if err != nil {
+		return nil, err
+	}
+	versions, err := modules.ReadFromFile(versionsPath)
+	if err != nil {
+		return newFindings(config.RepoRoot, RuleVersionsValid, SeverityError, "", versionsPath,
+			fmt.Errorf("versions.yaml parsing failed: %w", err)), nil
+	}
 
-	err = checkForValidMakefile(moduleAbsPath)
-	allErrors = errors.Join(allErrors, err)
+	findings := config.checkModule(configuredSeverities, &moduleToCheck{
+		repoRoot:      config.RepoRoot,
+		absModulePath: filepath.Dir(versionsPath),
+		versionsPath:  versionsPath,
+		versions:      versions,
+	})
+	sortFindings(findings)
+	return findings, nil
+}
 
-	if config.Strict {
-		err = checkForDanglingAutorelease(versions, moduleAbsPath)
-		allErrors = errors.Join(allErrors, err)
+// reportFindings logs the warnings and returns the errors joined
+func reportFindings(findings []Finding) error {
+	for _, finding := range findings {
+		if finding.Severity == SeverityWarning {
+			log.Warn("Lint warning", "rule", finding.Rule, "moduleID", finding.ModuleID, "file", finding.File, "issue", finding.Message)
+		}
 	}
-
-	return allErrors
+	return FindingsError(findings)
 }
 
-func checkForValidVersionsFile(repoRoot, versionsPath string) (*modules.Versions, error) {
-	versions, versionErrors := modules.ReadFromFile(versionsPath)
-	if versionErrors != nil {
-		versions = &modules.Versions{}
-		versionErrors = fmt.Errorf("versions.yaml parsing failed: %w", versionErrors)
+// checkModule runs the lint rules on the module according to their severity and returns their findings
+func (config *CheckConfig) checkModule(configuredSeverities map[string]Severity, module *moduleToCheck) []Finding {
+	severities, err := moduleSeverities(configuredSeverities, module.versions)
+	if err != nil {
+		return newFindings(config.RepoRoot, RuleVersionsValid, SeverityError, module.versions.ID, module.versionsPath, err)
+	}
+
+	findings := []Finding{}
+	for _, lintRule := range lintRules {
+		severity := severities[lintRule.name]
+		if severity == SeverityOff {
+			continue
+		}
+		err := lintRule.check(module)
+		if err == nil {
+			continue
+		}
+		defaultFile := module.versionsPath
+		if lintRule.file != nil {
+			defaultFile = lintRule.file(module)
+		}
+		findings = append(findings, newFindings(config.RepoRoot, lintRule.name, severity, module.versions.ID, defaultFile, err)...)
 	}
+	return findings
+}
 
+func checkForValidDependencies(repoRoot, versionsPath string, versions *modules.Versions) error {
+	var dependencyErrors error
 	for _, moduleDependency := range versions.Dependencies {
 		fullPath := filepath.Join(repoRoot, moduleDependency)
 		_, err := os.Stat(fullPath)
 		if err != nil {
-			versionErrors = errors.Join(versionErrors, fmt.Errorf("unable to locate module dependency '%s': %w", moduleDependency, err))
+			dependencyErrors = errors.Join(dependencyErrors, &locatedError{
+				file: versionsPath,
+				line: lineMatching(versionsPath, versionsYAMLEntryRegex(moduleDependency)),
+				err:  fmt.Errorf("unable to locate module dependency '%s': %w", moduleDependency, err),
+			})
 		}
 	}
-
-	return versions, versionErrors
+	return dependencyErrors
 }
 
 func checkForValidREADME(absModulePath string) error {
@@ -98,49 +169,67 @@ func checkForValidREADME(absModulePath string) error {
 	return nil
 }
 
+func checkForExistingChangelog(absModulePath string) error {
+	if _, _, err := findChangelog(absModulePath); err != nil {
+		return fmt.Errorf("existence check failed for CHANGELOG: a %s file is required for the module at %s",
+			supportedChangelogs(), absModulePath)
+	}
+	return nil
+}
+
+// checkForValidChangelog checks the released versions are in the changelog, a missing changelog
+// is reported by checkForExistingChangelog.
 func checkForValidChangelog(versions *modules.Versions, absModulePath string) error {
-	noCHANGESerr := checkExistence(changelogCHANGESFile, absModulePath)
-	if noCHANGESerr == nil {
-		err := validateCHANGESFile(filepath.Join(absModulePath, changelogCHANGESFile), versions)
-		if err != nil {
-			return fmt.Errorf("versions check failed for CHANGES: %s", err.Error())
-		}
+	changelogPath, parser, err := findChangelog(absModulePath)
+	if err != nil {
 		return nil
 	}
 
-	noChangelogMDerr := checkExistence(changelogMDFile, absModulePath)
-	if noChangelogMDerr == nil {
-		err := checkMarkdownChangelog(filepath.Join(absModulePath, changelogMDFile), versions)
-		if err != nil {
-			return fmt.Errorf("versions check failed for CHANGELOG: %s", err.Error())
-		}
-		return nil
+	err = parser.check(changelogPath, versions)
+	if err != nil {
+		return fmt.Errorf("versions check failed for %s: %s", filepath.Base(changelogPath), err.Error())
 	}
+	return nil
+}
 
-	specFile, noSpecErr := findSpecFile(absModulePath)
-	if noSpecErr == nil {
-		err := checkSpecChangelog(filepath.Join(absModulePath, specFile), versions)
-		if err != nil {
-			return fmt.Errorf("spec versions check failed: %s", err.Error())
-		}
+// checkChangelogDates checks the dates, order and uniqueness of the changelog entries if supported by the changelog,
+// each issue is a separate error located in the changelog.
+func checkChangelogDates(versions *modules.Versions, absModulePath string) error {
+	changelogPath, parser, err := findChangelog(absModulePath)
+	if err != nil || parser.checkDates == nil {
 		return nil
 	}
-
-	return fmt.Errorf(
-		"existence check failed for CHANGELOG: a %s, %s or .spec file is required for the module at %s",
-		changelogMDFile, changelogCHANGESFile, absModulePath,
-	)
+	return parser.checkDates(changelogPath, versions)
 }
 
 func checkForDanglingAutorelease(versions *modules.Versions, versionsPath string) error {
 	for _, release := range versions.ReleasedVersions {
 		if release.CommitID == autoReleaseHash {
-			return fmt.Errorf("dangling autorelease detected in %s for %s\nat: %s", versions.ID, release.Number, versionsPath)
+			return &locatedError{
+				file: versionsPath,
+				line: lineMatching(versionsPath, versionsYAMLEntryRegex(release.Number.String())),
+				err:  fmt.Errorf("dangling autorelease detected in %s for %s\nat: %s", versions.ID, release.Number, versionsPath),
+			}
 		}
 	}
 	return nil
 }
 
+func checkVersionsSorted(versionsPath string, versions *modules.Versions) error {
+	unsorted := versions.FirstUnsortedRelease()
+	if unsorted < 0 {
+		return nil
+	}
+	release := versions.ReleasedVersions[unsorted]
+	previous := versions.ReleasedVersions[unsorted-1]
+	return &locatedError{
+		file: versionsPath,
+		line: lineMatching(versionsPath, versionsYAMLEntryRegex(release.Number.String())),
+		err: fmt.Errorf("version '%s' released %s is listed after '%s' released %s in %s, versions must be in release order",
+			release.Number, release.Timestamp.Format(time.DateOnly), previous.Number, previous.Timestamp.Format(time.DateOnly), versionsPath),
+	}
+}
+
 func checkExistence(file, absModulePath string) error {
 	info, err := os.Stat(absModulePath)
 	if err != nil {
@@ -3,6 +3,7 @@ package lint
 import (
 	"path"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,6 +27,17 @@ versions:
     1.0.0: 1970-01-01T00:00:00Z|hash
     1.1.0: 1970-02-01T00:00:00Z|hash
 `
+const versionsYamlWithReleasesAndChangelogDatesError = `
+id: ch.open.tools:kaeter-police-tests
+type: Makefile
+versioning: SemVer
+metadata:
+    annotations:
+        open.ch/kaeter-lint/changelog-dates: error
+versions:
+    1.0.0: 2020-06-02T00:00:00Z|hash
+    1.1.0: 2020-07-02T00:00:00Z|hash
+`
 const versionsYamlWithVersionDashReleaseReleases = `
 id: ch.open.tools:kaeter-police-tests
 type: Makefile
@@ -62,6 +74,18 @@ versioning: SemVer
 versions:
     0.0.0: 1970-01-01T00:00:00Z|INIT
 `
+const versionsYamlReadmeWarning = `
+id: ch.open.tools:kaeter-police-tests
+type: Makefile
+versioning: SemVer
+metadata:
+    annotations:
+        open.ch/kaeter-lint/readme-exists: warning
+        open.ch/kaeter-lint/changelog-dates: "off"
+versions:
+    0.0.0: 1970-01-01T00:00:00Z|INIT
+    1.0.0: 1970-02-01T00:00:00Z|hash
+`
 const versionsYamlAutorelease = `
 id: ch.open.tools:kaeter-police-tests
 type: Makefile
@@ -84,6 +108,38 @@ v2.9  24.06.2021 jmj,pfi
 - something else
 `
 
+const changelogAsciiDocWithReleases = `= Changelog
+
+== 1.1.0 - 02.07.2020
+* Minor version
+
+== 1.0.0 - 02.06.2020
+* Initial version
+`
+const changelogRSTWithReleases = `Changelog
+=========
+
+[1.1.0] - 2020-07-02
+--------------------
+- Minor version
+
+[1.0.0] - 2020-06-02
+--------------------
+- Initial version
+`
+const changelogDebianWithReleases = `kaeter-police (1.1.0) unstable; urgency=medium
+
+  * Minor version
+
+ -- Jane Doe <jane@example.com>  Thu, 02 Jul 2020 10:00:00 +0200
+
+kaeter-police (1.0.0) unstable; urgency=medium
+
+  * Initial version
+
+ -- Jane Doe <jane@example.com>  Tue, 02 Jun 2020 10:00:00 +0200
+`
+
 const specFileName = "something-something.spec"
 const specChangelogWithReleases = `Name: testing-spec
 Version: 1.1.0
@@ -225,6 +281,31 @@ func TestCheckModuleFromVersionsFile(t *testing.T) {
 			module: mockModule{versions: versionsYamlWithVersionDashReleaseReleases, readme: "Test", changelog: specChangelogWithReleases, changelogName: specFileName},
 			valid:  true,
 		},
+		{
+			name:   "pass when all OK with CHANGELOG.adoc",
+			module: mockModule{versions: versionsYamlWithReleases, readme: "Test", changelog: changelogAsciiDocWithReleases, changelogName: changelogAsciiDocFile},
+			valid:  true,
+		},
+		{
+			name:   "pass when all OK with CHANGELOG.rst",
+			module: mockModule{versions: versionsYamlWithReleases, readme: "Test", changelog: changelogRSTWithReleases, changelogName: changelogRSTFile},
+			valid:  true,
+		},
+		{
+			name:   "pass when all OK with CRLF line endings in CHANGELOG.md",
+			module: mockModule{versions: versionsYamlWithReleases, readme: "Test", changelog: strings.ReplaceAll(changelogMDWithReleases, "\n", "\r\n"), changelogName: changelogMDFile},
+			valid:  true,
+		},
+		{
+			name:   "pass when all OK with CRLF line endings in CHANGELOG.rst",
+			module: mockModule{versions: versionsYamlWithReleases, readme: "Test", changelog: strings.ReplaceAll(changelogRSTWithReleases, "\n", "\r\n"), changelogName: changelogRSTFile},
+			valid:  true,
+		},
+		{
+			name:   "pass when all OK with debian/changelog",
+			module: mockModule{versions: versionsYamlWithReleases, readme: "Test", changelog: changelogDebianWithReleases, changelogName: changelogDebianFile},
+			valid:  true,
+		},
 		{
 			name:   "pass when all dependencies are valid",
 			module: mockModule{versions: versionsYamlValidDependencies, readme: "Test", changelog: specChangelogWithReleases, changelogName: specFileName, mockRepoFiles: []string{".gitignore"}},
@@ -235,6 +316,24 @@ func TestCheckModuleFromVersionsFile(t *testing.T) {
 			module: mockModule{versions: versionsYamlMinimal, readme: "", changelog: "Changelog", changelogName: changelogMDFile},
 			valid:  false,
 		},
+		{
+			name:   "pass when readme missing with the rule set to warning",
+			module: mockModule{versions: versionsYamlReadmeWarning, readme: "", changelog: "## 1.0.0 - 10.12.93", changelogName: changelogMDFile},
+			valid:  true,
+		},
+		{
+			name:   "passes if changelog dates mismatch as the rule is a warning by default",
+			module: mockModule{versions: versionsYamlWithReleases, readme: "Test", changelog: changelogMDWithReleases, changelogName: changelogMDFile},
+			valid:  true,
+		},
+		{
+			name:   "fails with the rule name if changelog dates mismatch",
+			module: mockModule{versions: versionsYamlWithReleasesAndChangelogDatesError, readme: "Test", changelog: "## 1.1.0 - 02.07.2020\n## 1.0.0 - 02.06.2019\n", changelogName: changelogMDFile},
+			valid:  false,
+			errorMatches: []string{
+				"[changelog-dates] date 2019-06-02 of version '1.0.0'",
+			},
+		},
 		{
 			name:   "fails if changelog missing",
 			module: mockModule{versions: versionsYamlMinimal, readme: "Test", changelog: "", changelogName: changelogMDFile},
@@ -250,6 +349,12 @@ func TestCheckModuleFromVersionsFile(t *testing.T) {
 			module: mockModule{versions: versionsYamlAnyStringVer, readme: "Test", changelog: "Missing Releases", changelogName: changelogCHANGESFile},
 			valid:  false,
 		},
+		{
+			name:         "fails if debian/changelog incomplete",
+			module:       mockModule{versions: versionsYamlAnyStringVer, readme: "Test", changelog: changelogDebianWithReleases, changelogName: changelogDebianFile},
+			valid:        false,
+			errorMatches: []string{"versions check failed for changelog"},
+		},
 		{
 			name:   "fails if .spec file changelog incomplete",
 			module: mockModule{versions: versionsYamlAnyStringVer, readme: "Test", changelog: "# Incomplete", changelogName: specFileName},
@@ -301,6 +406,7 @@ func TestCheckModuleFromVersionsFile(t *testing.T) {
 				mocks.CreateMockFile(t, modulePath, readmeFile, tt.module.readme)
 			}
 			if tt.module.changelog != "" && tt.module.changelogName != "" {
+				mocks.CreateMockFolder(t, modulePath, filepath.Dir(tt.module.changelogName))
 				mocks.CreateMockFile(t, modulePath, tt.module.changelogName, tt.module.changelog)
 			}
 			for _, fileToMock := range tt.module.mockRepoFiles {
@@ -0,0 +1,95 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/open-ch/kaeter/modules"
+)
+
+const changelogDebianFile = "debian/changelog"
+
+// debianEntryRegex matches the first line of a debian/changelog entry: [package, version, distributions and urgency]
+// i.e. kaeter (1.2.3-1) unstable; urgency=medium
+const debianEntryRegex = `(?m)^(\S+) \(([^()\s]+)\) ([^;\n]+;.*)$`
+
+// debianTrailerRegex matches the last line of a debian/changelog entry: [maintainer, date]
+// i.e. " -- Jane Doe <jane@example.com>  Sat, 02 Mar 2024 10:00:00 +0100"
+const debianTrailerRegex = `(?m)^ -- (.+?)  (\S.*\S)[ \t]*$`
+
+// unmarshalDebianChangelog builds a Changelog struct from a debian/changelog, see
+// https://www.debian.org/doc/debian-policy/ch-source.html#debian-changelog-debian-changelog
+// The epoch of the versions is dropped as kaeter versions can't have one.
+func unmarshalDebianChangelog(changelog string) (*Changelog, error) {
+	headerRegex := regexp.MustCompile(debianEntryRegex)
+	trailerRegex := regexp.MustCompile(debianTrailerRegex)
+	headers := headerRegex.FindAllStringSubmatchIndex(changelog, -1)
+
+	entries := make([]ChangelogEntry, len(headers))
+	for i, header := range headers {
+		versionStr := changelog[header[4]:header[5]]
+		if _, withoutEpoch, hasEpoch := strings.Cut(versionStr, ":"); hasEpoch {
+			versionStr = withoutEpoch
+		}
+		version, err := modules.UnmarshalVersionString(versionStr, modules.AnyStringVer)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing release version number: %w", err)
+		}
+
+		section := changelog[header[1]:]
+		if i+1 < len(headers) {
+			section = changelog[header[1]:headers[i+1][0]]
+		}
+		trailer := trailerRegex.FindStringSubmatchIndex(section)
+		if trailer == nil {
+			return nil, fmt.Errorf("maintainer line ( -- name <email>  date) not found for version %s", versionStr)
+		}
+		timestamp, err := time.Parse(time.RFC1123Z, section[trailer[4]:trailer[5]])
+		if err != nil {
+			return nil, fmt.Errorf("error parsing release timestamp of %s: %w", versionStr, err)
+		}
+
+		entries[i] = ChangelogEntry{
+			Version:   version,
+			Content:   &ChangelogEntryContent{dedentDebianChanges(section[:trailer[0]])},
+			Timestamp: &timestamp,
+			Line:      lineAt(changelog, header[0]),
+		}
+	}
+
+	return &Changelog{Entries: entries}, nil
+}
+
+// dedentDebianChanges removes the 2 spaces indenting the changes of a debian/changelog entry
+func dedentDebianChanges(changes string) string {
+	var dedented strings.Builder
+	for line := range strings.Lines(changes) {
+		dedented.WriteString(strings.TrimPrefix(line, "  "))
+	}
+	return dedented.String()
+}
+
+// insertDebianEntry adds the entry at the top of the changelog, the package name, distributions and
+// urgency are the ones of the latest entry.
+func insertDebianEntry(changelog string, entry *NewChangelogEntry) (string, error) {
+	latestHeader := regexp.MustCompile(debianEntryRegex).FindStringSubmatchIndex(changelog)
+	if latestHeader == nil {
+		return "", fmt.Errorf("no entry found to take the package name and distribution from")
+	}
+	packageName := changelog[latestHeader[2]:latestHeader[3]]
+	distribution := changelog[latestHeader[6]:latestHeader[7]]
+
+	var section strings.Builder
+	fmt.Fprintf(&section, "%s (%s) %s\n\n", packageName, entry.Version, distribution)
+	for _, change := range entry.Changes {
+		section.WriteString("  * " + change + "\n")
+	}
+	if len(entry.Changes) > 0 {
+		section.WriteString("\n")
+	}
+	fmt.Fprintf(&section, " -- %s  %s\n\n", entry.Author, entry.Date.Format(time.RFC1123Z))
+
+	return changelog[:latestHeader[0]] + section.String() + changelog[latestHeader[0]:], nil
+}
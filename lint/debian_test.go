@@ -0,0 +1,60 @@
+package lint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sampleDebianChangelog = `kaeter (1:1.1.0-1) unstable; urgency=medium
+
+  * Second release
+
+ -- Jane Doe <jane@example.com>  Tue, 26 May 2020 10:00:00 +0200
+
+kaeter (1.0.0-1) unstable; urgency=low
+
+  * Initial release
+
+ -- Jane Doe <jane@example.com>  Mon, 18 May 2020 23:30:00 -0100
+`
+
+func TestUnmarshalDebianChangelog(t *testing.T) {
+	changelog, err := unmarshalDebianChangelog(sampleDebianChangelog)
+
+	assert.NoError(t, err)
+	assert.Len(t, changelog.Entries, 2)
+	assert.Equal(t, "1.1.0-1", changelog.Entries[0].Version.String(), "the epoch is dropped")
+	assert.Equal(t, "\n\n* Second release\n\n", changelog.Entries[0].Content.Content)
+	assert.Equal(t, time.Date(2020, 5, 26, 8, 0, 0, 0, time.UTC), changelog.Entries[0].Timestamp.UTC())
+	assert.Equal(t, "1.0.0-1", changelog.Entries[1].Version.String())
+	assert.Equal(t, time.Date(2020, 5, 19, 0, 30, 0, 0, time.UTC), changelog.Entries[1].Timestamp.UTC())
+}
+
+func TestUnmarshalDebianChangelogErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		changelog     string
+		expectedError string
+	}{
+		{
+			name:          "Fails without maintainer line",
+			changelog:     "kaeter (1.0.0) unstable; urgency=low\n\n  * Initial release\n",
+			expectedError: "maintainer line",
+		},
+		{
+			name:          "Fails with invalid date",
+			changelog:     "kaeter (1.0.0) unstable; urgency=low\n\n  * Initial release\n\n -- Jane Doe <jane@example.com>  18.05.2020\n",
+			expectedError: "error parsing release timestamp of 1.0.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := unmarshalDebianChangelog(tt.changelog)
+
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// RuleVersionsValid reports modules whose versions.yaml can't be loaded, it is always an error
+const RuleVersionsValid = "versions-valid"
+
+// Finding is an issue detected by a lint rule in a module
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	ModuleID string   `json:"moduleId,omitempty"`
+	File     string   `json:"file,omitempty"` // Relative to the repository root
+	Line     int      `json:"line,omitempty"` // Starting at 1, 0 if unknown
+	Message  string   `json:"message"`
+}
+
+// locatedError is an issue found at a known line of a file
+type locatedError struct {
+	file string // Absolute path
+	line int
+	err  error
+}
+
+func (locatedErr *locatedError) Error() string {
+	return locatedErr.err.Error()
+}
+
+func (locatedErr *locatedError) Unwrap() error {
+	return locatedErr.err
+}
+
+// FindingsError returns the findings with the error severity joined, nil if there are none
+func FindingsError(findings []Finding) error {
+	var errs error
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			errs = errors.Join(errs, fmt.Errorf("[%s] %s", finding.Rule, finding.Message))
+		}
+	}
+	return errs
+}
+
+// newFindings returns a finding per error joined in err, located at the line of the file given by the error
+// if any, or else in the defaultFile of the rule.
+func newFindings(repoRoot, rule string, severity Severity, moduleID, defaultFile string, err error) []Finding {
+	findings := []Finding{}
+	for _, issue := range splitErrors(err) {
+		finding := Finding{
+			Rule:     rule,
+			Severity: severity,
+			ModuleID: moduleID,
+			File:     relativeToRepo(repoRoot, defaultFile),
+			Message:  issue.Error(),
+		}
+		if locatedErr, ok := errors.AsType[*locatedError](issue); ok {
+			finding.File = relativeToRepo(repoRoot, locatedErr.file)
+			finding.Line = locatedErr.line
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// splitErrors returns the errors joined with errors.Join as a flat list
+func splitErrors(err error) []error {
+	joinedErr, isJoined := err.(interface{ Unwrap() []error })
+	if !isJoined {
+		return []error{err}
+	}
+	var errs []error
+	for _, wrappedErr := range joinedErr.Unwrap() {
+		errs = append(errs, splitErrors(wrappedErr)...)
+	}
+	return errs
+}
+
+func relativeToRepo(repoRoot, path string) string {
+	if path == "" {
+		return ""
+	}
+	relativePath, err := filepath.Rel(repoRoot, path)
+	if err != nil || !filepath.IsLocal(relativePath) {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(relativePath)
+}
+
+// lineMatching returns the number of the first line of the file matching the regex, 0 if none matches
+func lineMatching(path string, lineRegex *regexp.Regexp) int {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	location := lineRegex.FindIndex(content)
+	if location == nil {
+		return 0
+	}
+	return lineAt(string(content), location[0])
+}
+
+// versionsYAMLEntryRegex matches the line of a version or of a dependency in versions.yaml
+func versionsYAMLEntryRegex(value string) *regexp.Regexp {
+	quoted := `["']?` + regexp.QuoteMeta(value) + `["']?`
+	return regexp.MustCompile(`(?m)^[ \t]*(?:` + quoted + `[ \t]*:|-[ \t]*` + quoted + `[ \t]*$)`)
+}
+
+// sortFindings orders the findings by file and line
+func sortFindings(findings []Finding) {
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		if fileOrder := strings.Compare(a.File, b.File); fileOrder != 0 {
+			return fileOrder
+		}
+		return a.Line - b.Line
+	})
+}
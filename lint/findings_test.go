@@ -0,0 +1,89 @@
+package lint
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-ch/kaeter/mocks"
+)
+
+func TestFindIssuesStartingFrom(t *testing.T) {
+	repoPath, _ := mocks.CreateMockRepo(t)
+	mocks.CreateKaeterModule(t, repoPath, &mocks.KaeterModuleConfig{
+		Path:              "module-a",
+		Makefile:          mocks.EmptyMakefileContent,
+		READMECreateEmpty: true,
+		CHANGELOG:         "# CHANGELOG\n\n## 1.0.0 - 01.01.21\n\n## 0.9.0 - 01.01.21\n",
+		VersionsYAML: `id: ch.open.kaeter:module-a
+type: Makefile
+versioning: SemVer
+metadata:
+  annotations:
+    open.ch/kaeter-lint/dangling-autorelease: warning
+versions:
+  0.0.0: 1970-01-01T00:00:00Z|INIT
+  0.9.0: 2020-01-01T00:00:00Z|hash
+  1.0.0: 2020-01-01T00:00:00Z|AUTORELEASE
+`,
+	})
+	mocks.CreateKaeterModule(t, repoPath, &mocks.KaeterModuleConfig{
+		Path:         "module-b",
+		Makefile:     mocks.EmptyMakefileContent,
+		VersionsYAML: "versions: [",
+	})
+
+	findings, err := FindIssuesStartingFrom(CheckConfig{RepoRoot: repoPath})
+
+	assert.NoError(t, err)
+	assert.Len(t, findings, 3)
+	assert.Equal(t, Finding{
+		Rule:     RuleChangelogDates,
+		Severity: SeverityWarning,
+		ModuleID: "ch.open.kaeter:module-a",
+		File:     "module-a/CHANGELOG.md",
+		Line:     5,
+		Message:  findings[0].Message,
+	}, findings[0])
+	assert.Contains(t, findings[0].Message, "date 2021-01-01 of version '0.9.0'")
+	assert.Equal(t, RuleDanglingAutorelease, findings[1].Rule)
+	assert.Equal(t, SeverityWarning, findings[1].Severity)
+	assert.Equal(t, "module-a/versions.yaml", findings[1].File)
+	assert.Equal(t, 10, findings[1].Line)
+	assert.Equal(t, RuleVersionsValid, findings[2].Rule)
+	assert.Equal(t, "module-b/versions.yaml", findings[2].File)
+
+	err = FindingsError(findings)
+	assert.ErrorContains(t, err, "[versions-valid]")
+	assert.NotContains(t, err.Error(), "[changelog-dates]", "warnings are not errors")
+	assert.NotContains(t, err.Error(), "[dangling-autorelease]", "warnings are not errors")
+}
+
+func TestFindModuleIssuesLocatesDependencies(t *testing.T) {
+	repoPath, _ := mocks.CreateMockRepo(t)
+	modulePath, _ := mocks.CreateKaeterModule(t, repoPath, &mocks.KaeterModuleConfig{
+		Path:              "module",
+		Makefile:          mocks.EmptyMakefileContent,
+		READMECreateEmpty: true,
+		CHANGELOG:         "# CHANGELOG\n",
+		VersionsYAML: `id: ch.open.kaeter:module
+type: Makefile
+versioning: SemVer
+dependencies:
+  - README.md
+  - missing/dependency
+versions:
+  0.0.0: 1970-01-01T00:00:00Z|INIT
+`,
+	})
+
+	findings, err := FindModuleIssues(CheckConfig{RepoRoot: repoPath}, filepath.Join(modulePath, "versions.yaml"))
+
+	assert.NoError(t, err)
+	assert.Len(t, findings, 1)
+	assert.Equal(t, RuleDependenciesExist, findings[0].Rule)
+	assert.Equal(t, "module/versions.yaml", findings[0].File)
+	assert.Equal(t, 6, findings[0].Line)
+	assert.Contains(t, findings[0].Message, "missing/dependency")
+}
@@ -0,0 +1,187 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/open-ch/kaeter/git"
+	"github.com/open-ch/kaeter/log"
+	"github.com/open-ch/kaeter/modules"
+)
+
+// versionsKeyRegex matches the line starting the versions of versions.yaml
+var versionsKeyRegex = regexp.MustCompile(`^versions[ \t]*:`)
+
+// Fix is a change made to a module by lint --fix
+type Fix struct {
+	Rule        string `json:"rule"`
+	ModuleID    string `json:"moduleId"`
+	File        string `json:"file"` // Relative to the repository root
+	Description string `json:"description"`
+}
+
+// fixedFile is a file changed by the fix of a rule
+type fixedFile struct {
+	path        string // Absolute path
+	description string
+}
+
+// FixModulesStartingFrom recursively looks for modules (having versions.yaml), or the ones selected by
+// config.Paths, and applies the fixes of the rules failing on them unless the rules are off.
+// Returns the changes made, the issues which can't be fixed are left for lint to report.
+// Fails only if the lint configuration is invalid.
+func FixModulesStartingFrom(config CheckConfig) ([]Fix, error) {
+	configuredSeverities, err := config.configuredSeverities()
+	if err != nil {
+		return nil, err
+	}
+
+	fixes := []Fix{}
+	for _, result := range config.findModules() {
+		if result.Err != nil {
+			continue // Reported as versions-valid by lint
+		}
+		fixes = append(fixes, config.fixModule(configuredSeverities, config.newModuleToCheck(result.Module))...)
+	}
+	return fixes, nil
+}
+
+// fixModule applies the fixes of the failing rules in the order the rules are checked
+func (config *CheckConfig) fixModule(configuredSeverities map[string]Severity, module *moduleToCheck) []Fix {
+	severities, err := moduleSeverities(configuredSeverities, module.versions)
+	if err != nil {
+		return nil // Reported as versions-valid by lint
+	}
+
+	fixes := []Fix{}
+	for _, lintRule := range lintRules {
+		if lintRule.fix == nil || severities[lintRule.name] == SeverityOff || lintRule.check(module) == nil {
+			continue
+		}
+		fixedFiles, err := lintRule.fix(module)
+		for _, fixed := range fixedFiles {
+			fixes = append(fixes, Fix{
+				Rule:        lintRule.name,
+				ModuleID:    module.versions.ID,
+				File:        relativeToRepo(config.RepoRoot, fixed.path),
+				Description: fixed.description,
+			})
+		}
+		if err != nil {
+			log.Warn("Unable to fix lint issue", "rule", lintRule.name, "moduleID", module.versions.ID, "error", err)
+		}
+	}
+	return fixes
+}
+
+// sortVersions reorders the lines of the versions in versions.yaml rather than saving the parsed versions,
+// which would also reformat the file, so that only the order of the versions changes.
+func sortVersions(module *moduleToCheck) ([]fixedFile, error) {
+	content, err := os.ReadFile(module.versionsPath)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.SplitAfter(string(content), "\n")
+	versionsStart := slices.IndexFunc(lines, func(line string) bool { return versionsKeyRegex.MatchString(line) })
+	if versionsStart < 0 {
+		return nil, fmt.Errorf("unable to locate the versions in %s", module.versionsPath)
+	}
+
+	versionLines := make(map[*modules.VersionMetadata]string)
+	var lineIndexes []int
+	for _, release := range module.versions.ReleasedVersions {
+		entryRegex := versionsYAMLEntryRegex(release.Number.String())
+		index := slices.IndexFunc(lines[versionsStart:], func(line string) bool { return entryRegex.MatchString(line) })
+		if index < 0 {
+			return nil, fmt.Errorf("unable to locate version %s in %s", release.Number, module.versionsPath)
+		}
+		versionLines[release] = lines[versionsStart+index]
+		lineIndexes = append(lineIndexes, versionsStart+index)
+	}
+
+	module.versions.SortReleases()
+	slices.Sort(lineIndexes)
+	for i, release := range module.versions.ReleasedVersions {
+		lines[lineIndexes[i]] = versionLines[release]
+	}
+	if err := os.WriteFile(module.versionsPath, []byte(strings.Join(lines, "")), 0600); err != nil {
+		return nil, fmt.Errorf("unable to save %s: %w", module.versionsPath, err)
+	}
+	return []fixedFile{{module.versionsPath, "sorted the versions by release date"}}, nil
+}
+
+func createREADME(module *moduleToCheck) ([]fixedFile, error) {
+	return initializeMissingFile(module, &modules.InitializationConfig{
+		InitReadme: true,
+		// The README template links the changelog only if the module has one
+		InitChangelog: checkExistence(changelogMDFile, module.absModulePath) == nil,
+	})
+}
+
+func createChangelog(module *moduleToCheck) ([]fixedFile, error) {
+	return initializeMissingFile(module, &modules.InitializationConfig{InitChangelog: true})
+}
+
+// initializeMissingFile creates the files enabled in the config from the templates of kaeter init
+func initializeMissingFile(module *moduleToCheck, config *modules.InitializationConfig) ([]fixedFile, error) {
+	config.ModulePath = module.absModulePath
+	config.ModuleID = module.versions.ID
+	config.VersioningScheme = module.versions.VersioningType
+	created, err := modules.InitializeMissingFiles(config)
+
+	fixedFiles := make([]fixedFile, len(created))
+	for i, path := range created {
+		fixedFiles[i] = fixedFile{path, "created from the template"}
+	}
+	return fixedFiles, err
+}
+
+// addMissingChangelogEntries adds an entry header for each version released after the latest version with an
+// entry in the changelog. Older missing entries are left for lint to report as adding them would need to
+// reorder the changelog.
+func addMissingChangelogEntries(module *moduleToCheck) ([]fixedFile, error) {
+	changelogPath, parser, err := findChangelog(module.absModulePath)
+	if err != nil {
+		return nil, err
+	}
+	changelogRaw, err := os.ReadFile(changelogPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load %s: %w", changelogPath, err)
+	}
+
+	var missingReleases []*modules.VersionMetadata
+	for _, release := range module.versions.ReleasedVersions {
+		if release.CommitID == modules.InitRef {
+			continue
+		}
+		_, found, err := parser.extractNotes(string(changelogRaw), release.Number.String())
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %w", changelogPath, err)
+		}
+		if found {
+			missingReleases = nil
+		} else {
+			missingReleases = append(missingReleases, release)
+		}
+	}
+
+	author := git.Author(module.repoRoot)
+	fixedFiles := []fixedFile{}
+	for _, release := range missingReleases {
+		_, added, err := AddChangelogEntry(module.absModulePath, &NewChangelogEntry{
+			Version: release.Number.String(),
+			Date:    release.Timestamp,
+			Author:  author,
+		})
+		if err != nil {
+			return fixedFiles, err
+		}
+		if added {
+			fixedFiles = append(fixedFiles, fixedFile{changelogPath, fmt.Sprintf("added an entry for %s", release.Number)})
+		}
+	}
+	return fixedFiles, nil
+}
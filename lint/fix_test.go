@@ -0,0 +1,98 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-ch/kaeter/mocks"
+	"github.com/open-ch/kaeter/modules"
+)
+
+func TestFixModulesStartingFrom(t *testing.T) {
+	repoPath, _ := mocks.CreateMockRepo(t)
+	mocks.CreateKaeterModule(t, repoPath, &mocks.KaeterModuleConfig{
+		Path:     "module-a",
+		Makefile: mocks.EmptyMakefileContent,
+		VersionsYAML: `id: ch.open.kaeter:module-a
+type: Makefile
+versioning: SemVer
+versions:
+  0.0.0: 1970-01-01T00:00:00Z|INIT
+  1.1.0: 2020-02-01T00:00:00Z|934b40f6862a2dc28f4045bd57d1832dfde10e55
+  1.0.0: 2020-01-01T00:00:00Z|aa4b40f6862a2dc28f4045bd57d1832dfde10e55
+`,
+	})
+	mocks.CreateKaeterModule(t, repoPath, &mocks.KaeterModuleConfig{
+		Path:              "module-b",
+		Makefile:          mocks.EmptyMakefileContent,
+		READMECreateEmpty: true,
+		CHANGELOG:         "# CHANGELOG\n\n## 1.1.0 - 01.02.20\n",
+		VersionsYAML: `id: ch.open.kaeter:module-b
+type: Makefile
+versioning: SemVer
+metadata:
+  annotations:
+    open.ch/kaeter-lint/readme-exists: off
+versions:
+  0.0.0: 1970-01-01T00:00:00Z|INIT
+  1.0.0: 2020-01-01T00:00:00Z|aa4b40f6862a2dc28f4045bd57d1832dfde10e55
+  1.1.0: 2020-02-01T00:00:00Z|934b40f6862a2dc28f4045bd57d1832dfde10e55
+`,
+	})
+	err := os.Remove(filepath.Join(repoPath, "module-b", "README.md"))
+	assert.NoError(t, err)
+
+	fixes, err := FixModulesStartingFrom(CheckConfig{RepoRoot: repoPath})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Fix{
+		{Rule: RuleVersionsSorted, ModuleID: "ch.open.kaeter:module-a", File: "module-a/versions.yaml", Description: "sorted the versions by release date"},
+		{Rule: RuleReadmeExists, ModuleID: "ch.open.kaeter:module-a", File: "module-a/README.md", Description: "created from the template"},
+		{Rule: RuleChangelogExists, ModuleID: "ch.open.kaeter:module-a", File: "module-a/CHANGELOG.md", Description: "created from the template"},
+		{Rule: RuleChangelogInSync, ModuleID: "ch.open.kaeter:module-a", File: "module-a/CHANGELOG.md", Description: "added an entry for 1.0.0"},
+		{Rule: RuleChangelogInSync, ModuleID: "ch.open.kaeter:module-a", File: "module-a/CHANGELOG.md", Description: "added an entry for 1.1.0"},
+	}, fixes)
+	assert.NoFileExists(t, filepath.Join(repoPath, "module-b", "README.md"), "rules which are off are not fixed")
+
+	findings, err := FindIssuesStartingFrom(CheckConfig{RepoRoot: repoPath})
+	assert.NoError(t, err)
+	assert.Len(t, findings, 1, "missing entries older than the changelog can't be fixed")
+	assert.Equal(t, RuleChangelogInSync, findings[0].Rule)
+	assert.Equal(t, "ch.open.kaeter:module-b", findings[0].ModuleID)
+}
+
+func TestSortVersionsOnlyChangesOrder(t *testing.T) {
+	const versionsHeader = `# Released by the payments team
+id: ch.open.kaeter:module-a
+type: Makefile
+versioning: SemVer
+metadata:
+    annotations:
+        open.ch/kaeter-owner: '@org/payments' # overrides CODEOWNERS
+        open.ch/kaeter-lint/readme-exists: warning
+versions:
+    0.0.0: 1970-01-01T00:00:00Z|INIT
+`
+	const version100 = "    1.0.0: 2020-01-01T00:00:00Z|aa4b40f6862a2dc28f4045bd57d1832dfde10e55 # first release\n"
+	const version110 = "    1.1.0: 2020-02-01T00:00:00Z|934b40f6862a2dc28f4045bd57d1832dfde10e55\n"
+	const dependencies = "dependencies:\n    - some/library\n"
+	repoPath, _ := mocks.CreateMockRepo(t)
+	modulePath, _ := mocks.CreateKaeterModule(t, repoPath, &mocks.KaeterModuleConfig{
+		Path:         "module-a",
+		Makefile:     mocks.EmptyMakefileContent,
+		VersionsYAML: versionsHeader + version110 + version100 + dependencies,
+	})
+	versionsPath := filepath.Join(modulePath, "versions.yaml")
+	versions, err := modules.ReadFromFile(versionsPath)
+	assert.NoError(t, err)
+
+	_, err = sortVersions(&moduleToCheck{repoRoot: repoPath, absModulePath: modulePath, versionsPath: versionsPath, versions: versions})
+
+	assert.NoError(t, err)
+	versionsYAML, err := os.ReadFile(versionsPath)
+	assert.NoError(t, err)
+	assert.Equal(t, versionsHeader+version100+version110+dependencies, string(versionsYAML))
+}
@@ -5,19 +5,22 @@ import (
 	"path/filepath"
 
 	"github.com/open-ch/kaeter/makefiles"
+	"github.com/open-ch/kaeter/modules"
 )
 
-func checkForValidMakefile(absModulePath string) error {
+// checkForValidMakefile checks that the module Makefile has a target for each step of the release pipeline.
+func checkForValidMakefile(versions *modules.Versions, absModulePath string) error {
+	pipeline, err := versions.GetReleasePipeline()
+	if err != nil {
+		return err
+	}
+
 	makefileName, err := makefiles.DetectModuleMakefile(absModulePath)
 	if err != nil {
 		return fmt.Errorf("unable to locatate Makefile.kaeter or Makefile: %w", err)
 	}
 
-	requiredKaeterTargets := []string{
-		"build",
-		"test",
-		"release",
-	}
+	requiredKaeterTargets := pipeline.Steps
 	output, err := makefiles.DryRunTarget(absModulePath, makefileName, requiredKaeterTargets)
 	if err != nil {
 		return fmt.Errorf("unable to validate %s targets in %s: %w\n%s", requiredKaeterTargets, filepath.Join(makefileName, absModulePath), err, output)
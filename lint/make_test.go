@@ -6,13 +6,15 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/open-ch/kaeter/mocks"
+	"github.com/open-ch/kaeter/modules"
 )
 
 func TestCheckForValidMakefile(t *testing.T) {
 	tests := []struct {
-		name   string
-		module mocks.KaeterModuleConfig
-		valid  bool
+		name     string
+		module   mocks.KaeterModuleConfig
+		pipeline *modules.ReleasePipeline
+		valid    bool
 	}{
 		{
 			name: "Accepts valid Makefile.kaeter",
@@ -82,13 +84,40 @@ test:`,
 			},
 			valid: false,
 		},
+		{
+			name: "Accepts configured release steps",
+			module: mocks.KaeterModuleConfig{
+				Makefile:     ".PHONY: compile publish\ncompile:\npublish:",
+				VersionsYAML: mocks.EmptyVersionsYAML,
+			},
+			pipeline: &modules.ReleasePipeline{Steps: []string{"compile", "publish"}},
+			valid:    true,
+		},
+		{
+			name: "Fail for missing configured release step",
+			module: mocks.KaeterModuleConfig{
+				Makefile:     mocks.EmptyMakefileContent,
+				VersionsYAML: mocks.EmptyVersionsYAML,
+			},
+			pipeline: &modules.ReleasePipeline{Steps: []string{"build", "publish"}},
+			valid:    false,
+		},
+		{
+			name: "Fail for invalid release pipeline",
+			module: mocks.KaeterModuleConfig{
+				Makefile:     mocks.EmptyMakefileContent,
+				VersionsYAML: mocks.EmptyVersionsYAML,
+			},
+			pipeline: &modules.ReleasePipeline{Steps: []string{"build", "release"}, DryRunUntil: "test"},
+			valid:    false,
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			modulePath, _ := mocks.CreateKaeterRepo(t, &tc.module)
 
-			err := checkForValidMakefile(modulePath)
+			err := checkForValidMakefile(&modules.Versions{Release: tc.pipeline}, modulePath)
 
 			if tc.valid {
 				assert.NoError(t, err)
@@ -0,0 +1,85 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ReleaseNotes are the notes of a single version extracted from the changelog of a module
+type ReleaseNotes struct {
+	Version   string `json:"version"`
+	Changelog string `json:"changelog"` // File name of the changelog in the module folder
+	Notes     string `json:"notes"`
+}
+
+// changesHeaderRegex matches any release line of a CHANGES file (see validateCHANGESFile)
+const changesHeaderRegex = `(?m)^\S+\s+\d{2}\.\d{2}\.\d{4}(?:\s+[,\w]+)?$`
+
+// specSectionRegex matches the start of a spec changelog entry or of the next spec section
+const specSectionRegex = `(?m)^(?:\* |%)`
+
+// GetReleaseNotes extracts the notes of the version from the changelog of the module, which is
+// looked up like lint does: CHANGES, CHANGELOG.md, CHANGELOG.adoc, CHANGELOG.rst, debian/changelog
+// and finally the %changelog section of a .spec file.
+func GetReleaseNotes(absModulePath, version string) (*ReleaseNotes, error) {
+	changelogPath, parser, err := findChangelog(absModulePath)
+	if err != nil {
+		return nil, err
+	}
+	changelogRaw, err := os.ReadFile(changelogPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load %s: %w", changelogPath, err)
+	}
+
+	notes, found, err := parser.extractNotes(string(changelogRaw), version)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read release notes from %s: %w", changelogPath, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("release notes for %s not found in %s", version, changelogPath)
+	}
+
+	return &ReleaseNotes{
+		Version:   version,
+		Changelog: strings.TrimPrefix(filepath.ToSlash(changelogPath), filepath.ToSlash(absModulePath)+"/"),
+		Notes:     notes,
+	}, nil
+}
+
+func extractCHANGESNotes(changelogRaw, version string) (string, bool, error) {
+	versionHeader, err := regexp.Compile(`(?m)^` + regexp.QuoteMeta(version) + `\s+\d{2}\.\d{2}\.\d{4}(?:\s+[,\w]+)?$`)
+	if err != nil {
+		return "", false, err
+	}
+	notes, found := sectionAfter(changelogRaw, versionHeader, regexp.MustCompile(changesHeaderRegex))
+	return notes, found, nil
+}
+
+func extractSpecNotes(changelogRaw, version string) (string, bool, error) {
+	_, changelogSection, found := strings.Cut(changelogRaw, "%changelog")
+	if !found {
+		return "", false, fmt.Errorf("%%changelog section not found")
+	}
+	versionHeader, err := regexp.Compile(`(?m)^\* [ .,<>@\w-]+ - ` + regexp.QuoteMeta(version) + `$`)
+	if err != nil {
+		return "", false, err
+	}
+	notes, found := sectionAfter(changelogSection, versionHeader, regexp.MustCompile(specSectionRegex))
+	return notes, found, nil
+}
+
+// sectionAfter returns the trimmed text following the first match of header up to the next match of nextHeader
+func sectionAfter(text string, header, nextHeader *regexp.Regexp) (string, bool) {
+	headerLocation := header.FindStringIndex(text)
+	if headerLocation == nil {
+		return "", false
+	}
+	section := text[headerLocation[1]:]
+	if nextLocation := nextHeader.FindStringIndex(section); nextLocation != nil {
+		section = section[:nextLocation[0]]
+	}
+	return strings.TrimSpace(section), true
+}
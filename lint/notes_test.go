@@ -0,0 +1,145 @@
+package lint
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-ch/kaeter/mocks"
+)
+
+func TestGetReleaseNotes(t *testing.T) {
+	tests := []struct {
+		name          string
+		changelogFile string
+		changelog     string
+		version       string
+		expectedNotes string
+		valid         bool
+	}{
+		{
+			name:          "Extracts notes from CHANGELOG.md",
+			changelogFile: "CHANGELOG.md",
+			changelog:     "# CHANGELOG\n\n## 1.1.0 - 26.5.20\n\n - JIRA-42 second release\n - more\n\n## 1.0.0 - 18.5.20\n\nInitial Release\n",
+			version:       "1.1.0",
+			expectedNotes: "- JIRA-42 second release\n - more",
+			valid:         true,
+		},
+		{
+			name:          "Extracts notes of the last entry from CHANGELOG.md",
+			changelogFile: "CHANGELOG.md",
+			changelog:     "# CHANGELOG\n\n## 1.1.0 - 26.5.20\n\n - JIRA-42 second release\n\n## 1.0.0 - 18.5.20\n\nInitial Release\n",
+			version:       "1.0.0",
+			expectedNotes: "Initial Release",
+			valid:         true,
+		},
+		{
+			name:          "Extracts notes from CHANGES",
+			changelogFile: "CHANGES",
+			changelog:     "v2.8  17.12.2020 author1\n- something\n\nv2.9  24.06.2021 author1,author2\n- something more\n- something else\n",
+			version:       "v2.8",
+			expectedNotes: "- something",
+			valid:         true,
+		},
+		{
+			name:          "Extracts notes from spec file",
+			changelogFile: "module.spec",
+			changelog: `Name: testing-spec
+Version: 1.0.0
+%changelog
+* Fri Aug 1 2042 author - 1.0.0-2
+- FIX: Fixes the output to always be 42
+- FIX: Another fix
+* Fri Aug 1 2042 author - 1.0.0-1
+- TRIVIAL: Initial version release
+`,
+			version:       "1.0.0-2",
+			expectedNotes: "- FIX: Fixes the output to always be 42\n- FIX: Another fix",
+			valid:         true,
+		},
+		{
+			name:          "Extracts notes from CHANGELOG.adoc",
+			changelogFile: "CHANGELOG.adoc",
+			changelog:     "= Changelog\n\n== 1.1.0 - 26.5.20\n\n* Second release\n\n== 1.0.0 - 18.5.20\n\n* Initial release\n",
+			version:       "1.1.0",
+			expectedNotes: "* Second release",
+			valid:         true,
+		},
+		{
+			name:          "Extracts notes from CHANGELOG.rst",
+			changelogFile: "CHANGELOG.rst",
+			changelog:     "Changelog\n=========\n\n1.1.0 - 26.5.20\n---------------\n\n- Second release\n\n1.0.0 - 18.5.20\n---------------\n\n- Initial release\n",
+			version:       "1.0.0",
+			expectedNotes: "- Initial release",
+			valid:         true,
+		},
+		{
+			name:          "Extracts notes from CHANGELOG.md with CRLF line endings",
+			changelogFile: "CHANGELOG.md",
+			changelog:     "# CHANGELOG\r\n\r\n## 1.1.0 - 26.5.20\r\n\r\n - Second release\r\n\r\n## 1.0.0 - 18.5.20\r\n\r\nInitial Release\r\n",
+			version:       "1.0.0",
+			expectedNotes: "Initial Release",
+			valid:         true,
+		},
+		{
+			name:          "Extracts notes from CHANGELOG.rst with CRLF line endings",
+			changelogFile: "CHANGELOG.rst",
+			changelog:     "Changelog\r\n=========\r\n\r\n1.1.0 - 26.5.20\r\n---------------\r\n\r\n- Second release\r\n\r\n1.0.0 - 18.5.20\r\n---------------\r\n\r\n- Initial release\r\n",
+			version:       "1.1.0",
+			expectedNotes: "- Second release",
+			valid:         true,
+		},
+		{
+			name:          "Extracts notes from debian/changelog",
+			changelogFile: "debian/changelog",
+			changelog: `module (1.1.0-1) unstable; urgency=medium
+
+  * Second release
+    with details
+
+ -- Jane Doe <jane@example.com>  Tue, 26 May 2020 10:00:00 +0200
+
+module (1.0.0-1) unstable; urgency=low
+
+  * Initial release
+
+ -- Jane Doe <jane@example.com>  Mon, 18 May 2020 10:00:00 +0200
+`,
+			version:       "1.1.0-1",
+			expectedNotes: "* Second release\n  with details",
+			valid:         true,
+		},
+		{
+			name:          "Fails for missing version",
+			changelogFile: "CHANGELOG.md",
+			changelog:     "# CHANGELOG\n\n## 1.0.0 - 18.5.20\n\nInitial Release\n",
+			version:       "2.0.0",
+		},
+		{
+			name:          "Fails without changelog",
+			changelogFile: "README.md",
+			changelog:     "# Module\n",
+			version:       "1.0.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modulePath := t.TempDir()
+			mocks.CreateMockFolder(t, modulePath, filepath.Dir(tt.changelogFile))
+			mocks.CreateMockFile(t, modulePath, tt.changelogFile, tt.changelog)
+
+			notes, err := GetReleaseNotes(modulePath, tt.version)
+
+			if !tt.valid {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.version, notes.Version)
+			assert.Equal(t, tt.changelogFile, notes.Changelog)
+			assert.Equal(t, tt.expectedNotes, notes.Notes)
+		})
+	}
+}
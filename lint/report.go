@@ -0,0 +1,207 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Output formats of the lint findings
+const (
+	FormatText   = "text"   // Warnings logged and errors returned (default)
+	FormatJSON   = "json"   // Array of findings
+	FormatSARIF  = "sarif"  // SARIF 2.1.0 log for code scanning
+	FormatGitHub = "github" // GitHub Actions workflow commands (::error file=...::message)
+	FormatGitLab = "gitlab" // GitLab Code Quality report
+)
+
+// Findings are the issues detected by lint
+type Findings []Finding
+
+// ErrorCount returns the number of findings with the error severity
+func (findings Findings) ErrorCount() int {
+	errorCount := 0
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			errorCount++
+		}
+	}
+	return errorCount
+}
+
+// ToJSON returns the findings as an indented json array
+func (findings Findings) ToJSON() (string, error) {
+	if findings == nil {
+		findings = Findings{}
+	}
+	findingsJSON, err := json.MarshalIndent(findings, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("could not marshal lint findings to JSON: %w", err)
+	}
+	return string(findingsJSON), nil
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// ToSARIF returns the findings as a SARIF 2.1.0 log, i.e. for GitHub code scanning
+func (findings Findings) ToSARIF() (string, error) {
+	driver := sarifDriver{
+		Name:           "kaeter",
+		InformationURI: "https://github.com/open-ch/kaeter",
+		Rules:          []sarifRule{{ID: RuleVersionsValid, ShortDescription: sarifMessage{"The versions.yaml of the module can be loaded"}}},
+	}
+	for _, lintRule := range Rules() {
+		driver.Rules = append(driver.Rules, sarifRule{ID: lintRule.Name, ShortDescription: sarifMessage{lintRule.Description}})
+	}
+
+	results := []sarifResult{}
+	for _, finding := range findings {
+		result := sarifResult{
+			RuleID:  finding.Rule,
+			Level:   string(finding.Severity), // error and warning are also SARIF levels
+			Message: sarifMessage{finding.Message},
+		}
+		if finding.File != "" {
+			location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: finding.File}}}
+			if finding.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: finding.Line}
+			}
+			result.Locations = []sarifLocation{location}
+		}
+		results = append(results, result)
+	}
+
+	sarifJSON, err := json.MarshalIndent(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("could not marshal lint findings to SARIF: %w", err)
+	}
+	return string(sarifJSON), nil
+}
+
+// ToGitHubAnnotations returns a workflow command per finding, GitHub Actions shows them inline in pull requests:
+// https://docs.github.com/en/actions/reference/workflow-commands-for-github-actions
+func (findings Findings) ToGitHubAnnotations() string {
+	var annotations strings.Builder
+	for _, finding := range findings {
+		properties := []string{}
+		if finding.File != "" {
+			properties = append(properties, "file="+escapeGitHubProperty(finding.File))
+		}
+		if finding.Line > 0 {
+			properties = append(properties, fmt.Sprintf("line=%d", finding.Line))
+		}
+		properties = append(properties, "title="+escapeGitHubProperty("kaeter lint "+finding.Rule))
+		fmt.Fprintf(&annotations, "::%s %s::%s\n", finding.Severity, strings.Join(properties, ","), escapeGitHubData(finding.Message))
+	}
+	return annotations.String()
+}
+
+func escapeGitHubData(data string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(data)
+}
+
+func escapeGitHubProperty(property string) string {
+	return strings.NewReplacer(":", "%3A", ",", "%2C").Replace(escapeGitHubData(property))
+}
+
+type gitLabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitLabLocation `json:"location"`
+}
+
+type gitLabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitLabLines `json:"lines"`
+}
+
+type gitLabLines struct {
+	Begin int `json:"begin"`
+}
+
+// ToGitLabCodeQuality returns the findings as a GitLab Code Quality report, merge requests show them inline
+// when uploaded as artifacts:reports:codequality:
+// https://docs.gitlab.com/ci/testing/code_quality/#code-quality-report-format
+func (findings Findings) ToGitLabCodeQuality() (string, error) {
+	issues := []gitLabIssue{}
+	for _, finding := range findings {
+		severity := "major"
+		if finding.Severity == SeverityWarning {
+			severity = "minor"
+		}
+		fingerprint := sha256.Sum256([]byte(strings.Join([]string{finding.Rule, finding.ModuleID, finding.File, finding.Message}, "\x00")))
+		issues = append(issues, gitLabIssue{
+			Description: finding.Message,
+			CheckName:   finding.Rule,
+			Fingerprint: hex.EncodeToString(fingerprint[:]),
+			Severity:    severity,
+			Location:    gitLabLocation{Path: finding.File, Lines: gitLabLines{Begin: max(finding.Line, 1)}},
+		})
+	}
+
+	reportJSON, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("could not marshal lint findings to a GitLab Code Quality report: %w", err)
+	}
+	return string(reportJSON), nil
+}
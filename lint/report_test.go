@@ -0,0 +1,68 @@
+package lint
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testFindings = Findings{ //nolint:gochecknoglobals
+	{Rule: RuleChangelogDates, Severity: SeverityError, ModuleID: "ch.open.kaeter:module", File: "module/CHANGELOG.md", Line: 3, Message: "date mismatch, 100% wrong\nsee versions.yaml"},
+	{Rule: RuleReadmeExists, Severity: SeverityWarning, ModuleID: "ch.open.kaeter:module", File: "module/versions.yaml", Message: "no README"},
+}
+
+func TestFindingsToGitHubAnnotations(t *testing.T) {
+	annotations := testFindings.ToGitHubAnnotations()
+
+	assert.Equal(t,
+		"::error file=module/CHANGELOG.md,line=3,title=kaeter lint changelog-dates::date mismatch, 100%25 wrong%0Asee versions.yaml\n"+
+			"::warning file=module/versions.yaml,title=kaeter lint readme-exists::no README\n",
+		annotations)
+}
+
+func TestFindingsToJSON(t *testing.T) {
+	findingsJSON, err := testFindings.ToJSON()
+	assert.NoError(t, err)
+	var findings []Finding
+	assert.NoError(t, json.Unmarshal([]byte(findingsJSON), &findings))
+	assert.Equal(t, []Finding(testFindings), findings)
+
+	emptyJSON, err := Findings(nil).ToJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", emptyJSON)
+}
+
+func TestFindingsToSARIF(t *testing.T) {
+	sarifJSON, err := testFindings.ToSARIF()
+	assert.NoError(t, err)
+
+	var sarif sarifLog
+	assert.NoError(t, json.Unmarshal([]byte(sarifJSON), &sarif))
+	assert.Equal(t, "2.1.0", sarif.Version)
+	assert.Len(t, sarif.Runs, 1)
+	assert.Len(t, sarif.Runs[0].Tool.Driver.Rules, len(lintRules)+1)
+	results := sarif.Runs[0].Results
+	assert.Len(t, results, 2)
+	assert.Equal(t, "changelog-dates", results[0].RuleID)
+	assert.Equal(t, "error", results[0].Level)
+	assert.Equal(t, "module/CHANGELOG.md", results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 3, results[0].Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Equal(t, "warning", results[1].Level)
+	assert.Nil(t, results[1].Locations[0].PhysicalLocation.Region, "no line known")
+}
+
+func TestFindingsToGitLabCodeQuality(t *testing.T) {
+	reportJSON, err := testFindings.ToGitLabCodeQuality()
+	assert.NoError(t, err)
+
+	var issues []gitLabIssue
+	assert.NoError(t, json.Unmarshal([]byte(reportJSON), &issues))
+	assert.Len(t, issues, 2)
+	assert.Equal(t, "major", issues[0].Severity)
+	assert.Equal(t, 3, issues[0].Location.Lines.Begin)
+	assert.Equal(t, "minor", issues[1].Severity)
+	assert.Equal(t, 1, issues[1].Location.Lines.Begin)
+	assert.NotEqual(t, issues[0].Fingerprint, issues[1].Fingerprint)
+	assert.Equal(t, 1, testFindings.ErrorCount())
+}
@@ -0,0 +1,219 @@
+package lint
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/open-ch/kaeter/makefiles"
+	"github.com/open-ch/kaeter/modules"
+)
+
+// Severity of the issues detected by a lint rule, only errors fail lint
+type Severity string
+
+// Supported severities, the severity of each rule is set with lint.rules in the kaeter config and
+// per module with open.ch/kaeter-lint/<rule> annotations in versions.yaml.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityOff     Severity = "off"
+)
+
+// Names of the lint rules, see lintRules for their descriptions
+const (
+	RuleReadmeExists        = "readme-exists"
+	RuleChangelogExists     = "changelog-exists"
+	RuleChangelogInSync     = "changelog-in-sync"
+	RuleChangelogDates      = "changelog-dates"
+	RuleMakefileTargets     = "makefile-targets"
+	RuleDanglingAutorelease = "dangling-autorelease"
+	RuleDependenciesExist   = "dependencies-exist"
+	RuleVersionsSorted      = "versions-sorted"
+)
+
+const lintRulesViperKey = "lint.rules"
+
+// ruleAnnotationPrefix overrides the severity of a rule for a module, i.e. open.ch/kaeter-lint/readme-exists: warning
+const ruleAnnotationPrefix = "open.ch/kaeter-lint/"
+
+// moduleToCheck is the module passed to the checks of the lint rules
+type moduleToCheck struct {
+	repoRoot      string
+	absModulePath string
+	versionsPath  string
+	versions      *modules.Versions
+}
+
+// rule is a named check of a module
+type rule struct {
+	name            string
+	description     string
+	defaultSeverity Severity
+	check           func(module *moduleToCheck) error
+	// file returns the file the issues relate to when they don't specify one, versions.yaml if nil
+	file func(module *moduleToCheck) string
+	// fix changes the module so that the check passes (lint --fix), nil if the issues can't be fixed safely
+	fix func(module *moduleToCheck) ([]fixedFile, error)
+}
+
+// Rule describes a lint rule
+type Rule struct {
+	Name        string
+	Description string
+}
+
+// Rules returns the lint rules in the order they are checked
+func Rules() []Rule {
+	rules := make([]Rule, len(lintRules))
+	for i, lintRule := range lintRules {
+		rules[i] = Rule{Name: lintRule.name, Description: lintRule.description}
+	}
+	return rules
+}
+
+// lintRules are the rules checked for each module in order
+//
+//nolint:gochecknoglobals
+var lintRules = []rule{
+	{
+		name:            RuleDependenciesExist,
+		description:     "The dependencies listed in versions.yaml are existing paths",
+		defaultSeverity: SeverityError,
+		check: func(module *moduleToCheck) error {
+			return checkForValidDependencies(module.repoRoot, module.versionsPath, module.versions)
+		},
+	},
+	{
+		name:            RuleVersionsSorted,
+		description:     "The versions of versions.yaml are listed in release order",
+		defaultSeverity: SeverityWarning,
+		check: func(module *moduleToCheck) error {
+			return checkVersionsSorted(module.versionsPath, module.versions)
+		},
+		fix: sortVersions,
+	},
+	{
+		name:            RuleReadmeExists,
+		description:     "The module has a README.md",
+		defaultSeverity: SeverityError,
+		check: func(module *moduleToCheck) error {
+			return checkForValidREADME(module.absModulePath)
+		},
+		fix: createREADME,
+	},
+	{
+		name:            RuleChangelogExists,
+		description:     "The module has a changelog",
+		defaultSeverity: SeverityError,
+		check: func(module *moduleToCheck) error {
+			return checkForExistingChangelog(module.absModulePath)
+		},
+		fix: createChangelog,
+	},
+	{
+		name:            RuleChangelogInSync,
+		description:     "Every released version has an entry in the changelog",
+		defaultSeverity: SeverityError,
+		check: func(module *moduleToCheck) error {
+			return checkForValidChangelog(module.versions, module.absModulePath)
+		},
+		file: moduleChangelog,
+		fix:  addMissingChangelogEntries,
+	},
+	{
+		name:            RuleChangelogDates,
+		description:     "The changelog entries are dated around the release dates of versions.yaml, in descending version order without duplicates",
+		defaultSeverity: SeverityWarning,
+		check: func(module *moduleToCheck) error {
+			return checkChangelogDates(module.versions, module.absModulePath)
+		},
+		file: moduleChangelog,
+	},
+	{
+		name:            RuleMakefileTargets,
+		description:     "The Makefile has a target for each release step",
+		defaultSeverity: SeverityError,
+		check: func(module *moduleToCheck) error {
+			return checkForValidMakefile(module.versions, module.absModulePath)
+		},
+		file: func(module *moduleToCheck) string {
+			makefileName, err := makefiles.DetectModuleMakefile(module.absModulePath)
+			if err != nil {
+				return module.versionsPath
+			}
+			return filepath.Join(module.absModulePath, makefileName)
+		},
+	},
+	{
+		name:            RuleDanglingAutorelease,
+		description:     "The module has no pending autorelease",
+		defaultSeverity: SeverityOff,
+		check: func(module *moduleToCheck) error {
+			return checkForDanglingAutorelease(module.versions, module.versionsPath)
+		},
+	},
+}
+
+// configuredSeverities returns the severity of each rule for all modules: the default, raised to error for
+// dangling-autorelease in strict mode, overridden by the kaeter config.
+func (config *CheckConfig) configuredSeverities() (map[string]Severity, error) {
+	severities := make(map[string]Severity, len(lintRules))
+	for _, lintRule := range lintRules {
+		severities[lintRule.name] = lintRule.defaultSeverity
+	}
+	if config.Strict {
+		severities[RuleDanglingAutorelease] = SeverityError
+	}
+
+	for name, value := range viper.GetStringMapString(lintRulesViperKey) {
+		if err := setSeverity(severities, name, value); err != nil {
+			return nil, fmt.Errorf("invalid %s in the config: %w", lintRulesViperKey, err)
+		}
+	}
+	return severities, nil
+}
+
+// moduleSeverities returns the configured severities overridden by the annotations of the module
+func moduleSeverities(configuredSeverities map[string]Severity, versions *modules.Versions) (map[string]Severity, error) {
+	severities := maps.Clone(configuredSeverities)
+	if versions.Metadata == nil {
+		return severities, nil
+	}
+	for annotation, value := range versions.Metadata.Annotations {
+		name, isRuleAnnotation := strings.CutPrefix(annotation, ruleAnnotationPrefix)
+		if !isRuleAnnotation {
+			continue
+		}
+		if err := setSeverity(severities, name, value); err != nil {
+			return nil, fmt.Errorf("invalid annotation %s in %s: %w", annotation, versions.ID, err)
+		}
+	}
+	return severities, nil
+}
+
+// moduleChangelog returns the path of the changelog of the module, versions.yaml if it has none
+func moduleChangelog(module *moduleToCheck) string {
+	changelogPath, _, err := findChangelog(module.absModulePath)
+	if err != nil {
+		return module.versionsPath
+	}
+	return changelogPath
+}
+
+func setSeverity(severities map[string]Severity, name, value string) error {
+	if _, exists := severities[name]; !exists {
+		return fmt.Errorf("unknown rule '%s', supported: %s", name, strings.Join(slices.Sorted(maps.Keys(severities)), ", "))
+	}
+	switch severity := Severity(value); severity {
+	case SeverityError, SeverityWarning, SeverityOff:
+		severities[name] = severity
+		return nil
+	default:
+		return fmt.Errorf("invalid severity '%s' for %s, supported: %s, %s or %s", value, name, SeverityError, SeverityWarning, SeverityOff)
+	}
+}
@@ -0,0 +1,92 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-ch/kaeter/modules"
+)
+
+func TestRuleSeverities(t *testing.T) {
+	tests := []struct {
+		name               string
+		strict             bool
+		configRules        map[string]string
+		annotations        map[string]string
+		expectedSeverities map[string]Severity
+		expectedError      string
+	}{
+		{
+			name: "Defaults",
+			expectedSeverities: map[string]Severity{
+				RuleReadmeExists:        SeverityError,
+				RuleMakefileTargets:     SeverityError,
+				RuleDanglingAutorelease: SeverityOff,
+			},
+		},
+		{
+			name:               "Strict enables dangling-autorelease",
+			strict:             true,
+			expectedSeverities: map[string]Severity{RuleDanglingAutorelease: SeverityError},
+		},
+		{
+			name:        "Config overrides defaults",
+			strict:      true,
+			configRules: map[string]string{RuleReadmeExists: "warning", RuleDanglingAutorelease: "off"},
+			expectedSeverities: map[string]Severity{
+				RuleReadmeExists:        SeverityWarning,
+				RuleDanglingAutorelease: SeverityOff,
+				RuleChangelogExists:     SeverityError,
+			},
+		},
+		{
+			name:        "Annotations override config",
+			configRules: map[string]string{RuleReadmeExists: "warning", RuleMakefileTargets: "off"},
+			annotations: map[string]string{
+				"open.ch/kaeter-lint/readme-exists": "off",
+				"open.ch/kaeter-owner":              "@org/team",
+			},
+			expectedSeverities: map[string]Severity{
+				RuleReadmeExists:    SeverityOff,
+				RuleMakefileTargets: SeverityOff,
+			},
+		},
+		{
+			name:          "Fails on unknown rule in config",
+			configRules:   map[string]string{"readme": "off"},
+			expectedError: "unknown rule 'readme'",
+		},
+		{
+			name:          "Fails on invalid severity in annotation",
+			annotations:   map[string]string{"open.ch/kaeter-lint/readme-exists": "info"},
+			expectedError: "invalid severity 'info' for readme-exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.configRules != nil {
+				viper.Set(lintRulesViperKey, tt.configRules)
+				t.Cleanup(viper.Reset)
+			}
+			config := &CheckConfig{Strict: tt.strict}
+			versions := &modules.Versions{ID: "ch.open.kaeter:unit-test", Metadata: &modules.Metadata{Annotations: tt.annotations}}
+
+			severities, err := config.configuredSeverities()
+			if err == nil {
+				severities, err = moduleSeverities(severities, versions)
+			}
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			for rule, expectedSeverity := range tt.expectedSeverities {
+				assert.Equal(t, expectedSeverity, severities[rule], rule)
+			}
+		})
+	}
+}
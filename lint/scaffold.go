@@ -0,0 +1,155 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// NewChangelogEntry describes the entry to add to the changelog of a module for a new release
+type NewChangelogEntry struct {
+	Version string
+	Date    time.Time
+	Author  string   // Only used by spec changelogs, i.e. Jane Doe <jane@example.com>
+	Changes []string // One line per change, i.e. the commit subjects since the previous release
+}
+
+// AddChangelogEntry inserts an entry header for the new version, followed by its changes, in the
+// changelog of the module using the format lint expects. Entries are added at the top of the changelog,
+// of the spec %changelog, and at the bottom of CHANGES. Nothing is changed if the changelog already
+// has an entry for the version, added is false in that case.
+func AddChangelogEntry(absModulePath string, entry *NewChangelogEntry) (changelogPath string, added bool, err error) {
+	changelogPath, parser, err := findChangelog(absModulePath)
+	if err != nil {
+		return "", false, err
+	}
+	if _, err := GetReleaseNotes(absModulePath, entry.Version); err == nil {
+		return changelogPath, false, nil
+	}
+	changelogInfo, err := os.Stat(changelogPath)
+	if err != nil {
+		return "", false, err
+	}
+	changelogRaw, err := os.ReadFile(changelogPath)
+	if err != nil {
+		return "", false, fmt.Errorf("unable to load %s: %w", changelogPath, err)
+	}
+
+	updatedChangelog, err := parser.addEntry(string(changelogRaw), entry)
+	if err != nil {
+		return "", false, fmt.Errorf("unable to add entry for %s to %s: %w", entry.Version, changelogPath, err)
+	}
+
+	err = os.WriteFile(changelogPath, []byte(updatedChangelog), changelogInfo.Mode().Perm())
+	if err != nil {
+		return "", false, fmt.Errorf("unable to save %s: %w", changelogPath, err)
+	}
+	return changelogPath, true, nil
+}
+
+// insertMarkupEntry adds the entry before the latest release using the configured format, or else the format
+// already used by the changelog. With Keep a Changelog the changes of the [Unreleased] section are moved to the
+// entry instead of the given ones.
+func insertMarkupEntry(changelog string, entry *NewChangelogEntry, syntax markupSyntax) (string, error) {
+	format, err := changelogFormat()
+	if err != nil {
+		return "", err
+	}
+	if format == ChangelogFormatAuto {
+		format = syntax.detectFormat(changelog)
+	}
+
+	title := fmt.Sprintf("%s - %s", entry.Version, entry.Date.Format("02.01.06"))
+	changes := changeLines(entry.Changes)
+	if format == ChangelogFormatKeepAChangelog {
+		title = fmt.Sprintf("[%s] - %s", entry.Version, entry.Date.Format(time.DateOnly))
+		var unreleasedChanges string
+		changelog, unreleasedChanges = cutUnreleasedChanges(changelog, syntax)
+		if unreleasedChanges != "" {
+			changes = unreleasedChanges + "\n"
+		}
+	}
+	section := syntax.sectionTitle(changelog, title)
+	if changes != "" {
+		section += "\n" + changes
+	}
+	section += "\n"
+
+	latestEntry := syntax.entryHeaderRegex(ChangelogFormatAuto).FindStringIndex(changelog)
+	if latestEntry == nil {
+		return strings.TrimRight(changelog, "\n") + "\n\n" + section, nil
+	}
+	return changelog[:latestEntry[0]] + section + changelog[latestEntry[0]:], nil
+}
+
+// cutUnreleasedChanges removes the content of the [Unreleased] section from the changelog and returns it
+func cutUnreleasedChanges(changelog string, syntax markupSyntax) (updatedChangelog, unreleasedChanges string) {
+	unreleasedHeader := syntax.unreleasedRegex().FindStringIndex(changelog)
+	if unreleasedHeader == nil {
+		return changelog, ""
+	}
+	sectionStart := unreleasedHeader[1]
+	sectionEnd := len(changelog)
+	if nextSection := syntax.anySectionRegex().FindStringIndex(changelog[sectionStart:]); nextSection != nil {
+		sectionEnd = sectionStart + nextSection[0]
+	}
+	unreleasedChanges = strings.TrimSpace(changelog[sectionStart:sectionEnd])
+	if unreleasedChanges == "" {
+		return changelog, ""
+	}
+	return changelog[:sectionStart] + "\n\n" + changelog[sectionEnd:], unreleasedChanges
+}
+
+// sectionTitle returns the header line(s) of a new entry, reStructuredText titles are underlined
+// like the existing entries.
+func (syntax markupSyntax) sectionTitle(changelog, title string) string {
+	if !syntax.underlined {
+		return syntax.sectionPrefix + title + "\n"
+	}
+	underline := "-"
+	if latestEntry := syntax.entryHeaderRegex(ChangelogFormatAuto).FindString(changelog); latestEntry != "" {
+		_, existingUnderline, _ := strings.Cut(latestEntry, "\n")
+		underline = existingUnderline[:1]
+	}
+	return title + "\n" + strings.Repeat(underline, len(title)) + "\n"
+}
+
+func appendCHANGESEntry(changes string, entry *NewChangelogEntry) string {
+	section := fmt.Sprintf("%s %s\n", entry.Version, entry.Date.Format("02.01.2006")) + changeLines(entry.Changes)
+	if strings.TrimSpace(changes) == "" {
+		return section
+	}
+	return strings.TrimRight(changes, "\n") + "\n\n" + section
+}
+
+func insertSpecEntry(spec string, entry *NewChangelogEntry) (string, error) {
+	changelogStart := regexp.MustCompile(`(?m)^%changelog[ \t]*(?:\n|$)`).FindStringIndex(spec)
+	if changelogStart == nil {
+		return "", fmt.Errorf("%%changelog section not found")
+	}
+	header := "* " + entry.Date.Format("Mon Jan 2 2006")
+	if entry.Author != "" {
+		header += " " + entry.Author
+	}
+	section := header + " - " + entry.Version + "\n" + changeLines(entry.Changes)
+
+	insertAt := changelogStart[1]
+	if !strings.HasSuffix(spec[:insertAt], "\n") {
+		spec += "\n"
+		insertAt++
+	}
+	if strings.TrimSpace(spec[insertAt:]) != "" {
+		section += "\n"
+	}
+	return spec[:insertAt] + section + spec[insertAt:], nil
+}
+
+func changeLines(changes []string) string {
+	var lines strings.Builder
+	for _, change := range changes {
+		lines.WriteString("- " + change + "\n")
+	}
+	return lines.String()
+}
@@ -0,0 +1,165 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-ch/kaeter/mocks"
+)
+
+func TestAddChangelogEntry(t *testing.T) {
+	tests := []struct {
+		name              string
+		changelogFile     string
+		changelog         string
+		version           string
+		changes           []string
+		format            string
+		expectedChangelog string
+		expectedAdded     bool
+	}{
+		{
+			name:              "Adds entry at the top of CHANGELOG.md",
+			changelogFile:     "CHANGELOG.md",
+			changelog:         "# CHANGELOG\n\n## 1.0.0 - 18.5.20\n\nInitial Release\n",
+			version:           "1.1.0",
+			changes:           []string{"feat: add main", "fix: typo"},
+			expectedChangelog: "# CHANGELOG\n\n## 1.1.0 - 02.03.24\n\n- feat: add main\n- fix: typo\n\n## 1.0.0 - 18.5.20\n\nInitial Release\n",
+			expectedAdded:     true,
+		},
+		{
+			name:              "Adds first entry to CHANGELOG.md",
+			changelogFile:     "CHANGELOG.md",
+			changelog:         "# CHANGELOG\n",
+			version:           "1.0.0",
+			expectedChangelog: "# CHANGELOG\n\n## 1.0.0 - 02.03.24\n\n",
+			expectedAdded:     true,
+		},
+		{
+			name:              "Moves unreleased changes to the new Keep a Changelog entry",
+			changelogFile:     "CHANGELOG.md",
+			changelog:         "# Changelog\n\n## [Unreleased]\n\n### Added\n- New flag\n\n## [1.0.0] - 2024-01-01\n\n- Initial\n",
+			version:           "1.1.0",
+			changes:           []string{"feat: add flag"},
+			expectedChangelog: "# Changelog\n\n## [Unreleased]\n\n## [1.1.0] - 2024-03-02\n\n### Added\n- New flag\n\n## [1.0.0] - 2024-01-01\n\n- Initial\n",
+			expectedAdded:     true,
+		},
+		{
+			name:              "Lists changes in Keep a Changelog entry without unreleased changes",
+			changelogFile:     "CHANGELOG.md",
+			changelog:         "# Changelog\n\n## [Unreleased]\n\n## [1.0.0] - 2024-01-01\n",
+			version:           "1.1.0",
+			changes:           []string{"feat: add flag"},
+			expectedChangelog: "# Changelog\n\n## [Unreleased]\n\n## [1.1.0] - 2024-03-02\n\n- feat: add flag\n\n## [1.0.0] - 2024-01-01\n",
+			expectedAdded:     true,
+		},
+		{
+			name:              "Uses configured format for first entry",
+			changelogFile:     "CHANGELOG.md",
+			changelog:         "# Changelog\n",
+			version:           "1.0.0",
+			format:            ChangelogFormatKeepAChangelog,
+			expectedChangelog: "# Changelog\n\n## [1.0.0] - 2024-03-02\n\n",
+			expectedAdded:     true,
+		},
+		{
+			name:              "Adds entry at the top of CHANGELOG.adoc",
+			changelogFile:     "CHANGELOG.adoc",
+			changelog:         "= Changelog\n\n== 1.0.0 - 18.5.20\n\n- Initial\n",
+			version:           "1.1.0",
+			changes:           []string{"feat: add main"},
+			expectedChangelog: "= Changelog\n\n== 1.1.0 - 02.03.24\n\n- feat: add main\n\n== 1.0.0 - 18.5.20\n\n- Initial\n",
+			expectedAdded:     true,
+		},
+		{
+			name:              "Adds entry underlined like the others at the top of CHANGELOG.rst",
+			changelogFile:     "CHANGELOG.rst",
+			changelog:         "Changelog\n=========\n\n[Unreleased]\n~~~~~~~~~~~~\n\n- New flag\n\n[1.0.0] - 2024-01-01\n~~~~~~~~~~~~~~~~~~~~\n\n- Initial\n",
+			version:           "1.1.0",
+			changes:           []string{"feat: add flag"},
+			expectedChangelog: "Changelog\n=========\n\n[Unreleased]\n~~~~~~~~~~~~\n\n[1.1.0] - 2024-03-02\n~~~~~~~~~~~~~~~~~~~~\n\n- New flag\n\n[1.0.0] - 2024-01-01\n~~~~~~~~~~~~~~~~~~~~\n\n- Initial\n",
+			expectedAdded:     true,
+		},
+		{
+			name:              "Adds entry at the top of debian/changelog",
+			changelogFile:     "debian/changelog",
+			changelog:         "module (1.0.0-1) unstable; urgency=low\n\n  * Initial\n\n -- Joe <joe@example.com>  Mon, 01 Jan 2024 10:00:00 +0000\n",
+			version:           "1.0.0-2",
+			changes:           []string{"fix: typo"},
+			expectedChangelog: "module (1.0.0-2) unstable; urgency=low\n\n  * fix: typo\n\n -- Jane Doe <jane@example.com>  Sat, 02 Mar 2024 10:00:00 +0000\n\nmodule (1.0.0-1) unstable; urgency=low\n\n  * Initial\n\n -- Joe <joe@example.com>  Mon, 01 Jan 2024 10:00:00 +0000\n",
+			expectedAdded:     true,
+		},
+		{
+			name:              "Adds entry at the bottom of CHANGES",
+			changelogFile:     "CHANGES",
+			changelog:         "v2.8  17.12.2020 author1\n- something\n",
+			version:           "v2.9",
+			changes:           []string{"feat: add main"},
+			expectedChangelog: "v2.8  17.12.2020 author1\n- something\n\nv2.9 02.03.2024\n- feat: add main\n",
+			expectedAdded:     true,
+		},
+		{
+			name:              "Adds entry at the top of the spec changelog",
+			changelogFile:     "module.spec",
+			changelog:         "Name: module\n%changelog\n* Fri Aug 1 2042 author - 1.0.0-1\n- Initial version\n",
+			version:           "1.0.0-2",
+			changes:           []string{"fix: typo"},
+			expectedChangelog: "Name: module\n%changelog\n* Sat Mar 2 2024 Jane Doe <jane@example.com> - 1.0.0-2\n- fix: typo\n\n* Fri Aug 1 2042 author - 1.0.0-1\n- Initial version\n",
+			expectedAdded:     true,
+		},
+		{
+			name:              "Adds first entry to the spec changelog",
+			changelogFile:     "module.spec",
+			changelog:         "Name: module\n%changelog",
+			version:           "1.0.0-1",
+			expectedChangelog: "Name: module\n%changelog\n* Sat Mar 2 2024 Jane Doe <jane@example.com> - 1.0.0-1\n",
+			expectedAdded:     true,
+		},
+		{
+			name:              "Keeps existing entry",
+			changelogFile:     "CHANGELOG.md",
+			changelog:         "# CHANGELOG\n\n## 1.0.0 - 18.5.20\n\nInitial Release\n",
+			version:           "1.0.0",
+			changes:           []string{"feat: add main"},
+			expectedChangelog: "# CHANGELOG\n\n## 1.0.0 - 18.5.20\n\nInitial Release\n",
+			expectedAdded:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set(changelogFormatViperKey, tt.format)
+			t.Cleanup(viper.Reset)
+			modulePath := t.TempDir()
+			mocks.CreateMockFolder(t, modulePath, filepath.Dir(tt.changelogFile))
+			mocks.CreateMockFile(t, modulePath, tt.changelogFile, tt.changelog)
+
+			changelogPath, added, err := AddChangelogEntry(modulePath, &NewChangelogEntry{
+				Version: tt.version,
+				Date:    time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC),
+				Author:  "Jane Doe <jane@example.com>",
+				Changes: tt.changes,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedAdded, added)
+			assert.Equal(t, filepath.Join(modulePath, tt.changelogFile), changelogPath)
+			changelog, err := os.ReadFile(changelogPath)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedChangelog, string(changelog))
+			_, err = GetReleaseNotes(modulePath, tt.version)
+			assert.NoError(t, err, "the entry should be found")
+		})
+	}
+}
+
+func TestAddChangelogEntryFailsWithoutChangelog(t *testing.T) {
+	_, _, err := AddChangelogEntry(t.TempDir(), &NewChangelogEntry{Version: "1.0.0", Date: time.Now()})
+
+	assert.Error(t, err)
+}
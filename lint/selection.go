@@ -0,0 +1,106 @@
+package lint
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/open-ch/kaeter/inventory"
+	"github.com/open-ch/kaeter/modules"
+)
+
+// moduleSelection decides which of the modules found in the repository are linted
+type moduleSelection struct {
+	repoRoot string
+	paths    []string // Relative to the repository root, cleaned
+}
+
+func newModuleSelection(repoRoot string, paths []string) *moduleSelection {
+	if len(paths) == 0 {
+		return nil
+	}
+	selection := &moduleSelection{repoRoot: repoRoot}
+	for _, path := range paths {
+		selection.paths = append(selection.paths, filepath.Clean(path))
+	}
+	return selection
+}
+
+// filter returns the results to lint: the modules in the selected paths (or the module containing a path
+// without modules in it), the modules depending on them and the modules failing to load in the paths.
+// All results are returned if there is no selection.
+func (selection *moduleSelection) filter(results []modules.FindResult) []modules.FindResult {
+	if selection == nil {
+		return results
+	}
+
+	loaded := &inventory.Inventory{ModuleInventory: inventory.ModuleInventory{RepoRoot: selection.repoRoot}}
+	for _, result := range results {
+		if result.Err == nil {
+			loaded.ModuleInventory.Modules = append(loaded.ModuleInventory.Modules, *result.Module)
+		}
+	}
+
+	selected := map[string]bool{}
+	for _, path := range selection.paths {
+		found := false
+		for _, module := range loaded.ModuleInventory.Modules {
+			if inventory.IsWithinPath(path, module.ModulePath) {
+				selected[module.ModuleID] = true
+				found = true
+			}
+		}
+		if !found {
+			if module := loaded.FindModuleContainingPath(path); module != nil {
+				selected[module.ModuleID] = true
+			}
+		}
+	}
+	selection.addDependents(loaded, selected)
+
+	var filtered []modules.FindResult
+	for _, result := range results {
+		switch {
+		case result.Err == nil && selected[result.Module.ModuleID]:
+			filtered = append(filtered, result)
+		case result.Err != nil && selection.selectsFailedModule(result.ErrPath()):
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
+
+// addDependents adds the modules which depend on a selected module until none is left, a module depends
+// on another if one of its dependency paths is part of the other module.
+func (*moduleSelection) addDependents(loaded *inventory.Inventory, selected map[string]bool) {
+	for added := true; added; {
+		added = false
+		for _, module := range loaded.ModuleInventory.Modules {
+			if selected[module.ModuleID] {
+				continue
+			}
+			dependsOnSelected := slices.ContainsFunc(module.Dependencies, func(dependency string) bool {
+				dependencyModule := loaded.FindModuleContainingPath(dependency)
+				return dependencyModule != nil && selected[dependencyModule.ModuleID]
+			})
+			if dependsOnSelected {
+				selected[module.ModuleID] = true
+				added = true
+			}
+		}
+	}
+}
+
+// selectsFailedModule returns whether the module of the versions.yaml failing to load is in or contains a
+// selected path, failures without a path (i.e. the search itself failing) are always selected.
+func (selection *moduleSelection) selectsFailedModule(versionsPath string) bool {
+	if versionsPath == "" {
+		return true
+	}
+	modulePath, err := modules.GetRelativeModulePathFrom(versionsPath, selection.repoRoot)
+	if err != nil {
+		return true
+	}
+	return slices.ContainsFunc(selection.paths, func(path string) bool {
+		return inventory.IsWithinPath(path, modulePath) || inventory.IsWithinPath(modulePath, path)
+	})
+}
@@ -0,0 +1,105 @@
+package lint
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-ch/kaeter/mocks"
+	"github.com/open-ch/kaeter/modules"
+)
+
+func TestModuleSelectionFilter(t *testing.T) {
+	results := []modules.FindResult{
+		{Module: &modules.KaeterModule{ModuleID: "root", ModulePath: "."}},
+		{Module: &modules.KaeterModule{ModuleID: "lib", ModulePath: "libs/lib"}},
+		{Module: &modules.KaeterModule{ModuleID: "lib-nested", ModulePath: "libs/lib/nested"}},
+		{Module: &modules.KaeterModule{ModuleID: "app", ModulePath: "apps/app", Dependencies: []string{"libs/lib/src"}}},
+		{Module: &modules.KaeterModule{ModuleID: "app-image", ModulePath: "apps/app-image", Dependencies: []string{"apps/app/"}}},
+		{Module: &modules.KaeterModule{ModuleID: "other", ModulePath: "apps/other", Dependencies: []string{"libs/lib/nested"}}},
+		{Err: errors.New("search failed")},
+	}
+	var tests = []struct {
+		name        string
+		paths       []string
+		expectedIDs []string
+	}{
+		{
+			name:        "all modules without paths",
+			paths:       nil,
+			expectedIDs: []string{"root", "lib", "lib-nested", "app", "app-image", "other", "error"},
+		},
+		{
+			name:        "modules in the path and their dependents",
+			paths:       []string{"libs/lib"},
+			expectedIDs: []string{"lib", "lib-nested", "app", "app-image", "other", "error"},
+		},
+		{
+			name:        "dependents of dependents",
+			paths:       []string{"apps/app"},
+			expectedIDs: []string{"app", "app-image", "error"},
+		},
+		{
+			name:        "innermost module containing the path",
+			paths:       []string{"libs/lib/nested/src/main.go"},
+			expectedIDs: []string{"lib-nested", "other", "error"},
+		},
+		{
+			name:        "module without dependents",
+			paths:       []string{"apps/other/"},
+			expectedIDs: []string{"other", "error"},
+		},
+		{
+			name:        "repository root",
+			paths:       []string{"."},
+			expectedIDs: []string{"root", "lib", "lib-nested", "app", "app-image", "other", "error"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filtered := newModuleSelection("/repo", tc.paths).filter(results)
+
+			var ids []string
+			for _, result := range filtered {
+				if result.Err != nil {
+					ids = append(ids, "error")
+				} else {
+					ids = append(ids, result.Module.ModuleID)
+				}
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func TestFindIssuesInPaths(t *testing.T) {
+	repoPath, _ := mocks.CreateMockRepo(t)
+	for _, modulePath := range []string{"module-a", "module-b"} {
+		mocks.CreateKaeterModule(t, repoPath, &mocks.KaeterModuleConfig{
+			Path:         modulePath,
+			Makefile:     mocks.EmptyMakefileContent,
+			VersionsYAML: mocks.GetEmptyVersionsYaml(t, "ch.open.kaeter:"+modulePath),
+		})
+	}
+	mocks.CreateKaeterModule(t, repoPath, &mocks.KaeterModuleConfig{
+		Path:         "module-c",
+		VersionsYAML: "versions: [",
+	})
+
+	findings, err := FindIssuesStartingFrom(CheckConfig{RepoRoot: repoPath, Paths: []string{"module-b"}})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, findings)
+	for _, finding := range findings {
+		assert.Equal(t, "ch.open.kaeter:module-b", finding.ModuleID)
+	}
+
+	findings, err = FindIssuesStartingFrom(CheckConfig{RepoRoot: repoPath, Paths: []string{"module-c"}})
+
+	assert.NoError(t, err)
+	assert.Len(t, findings, 1)
+	assert.Equal(t, RuleVersionsValid, findings[0].Rule)
+	assert.Equal(t, "module-c/versions.yaml", findings[0].File)
+}
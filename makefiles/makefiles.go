@@ -1,10 +1,16 @@
 package makefiles
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"time"
+
+	"github.com/open-ch/kaeter/process"
 )
 
 // DetectModuleMakefile finds out if this modules has a
@@ -30,23 +36,86 @@ func DetectModuleMakefile(modulePath string) (string, error) {
 	return makefileName, nil
 }
 
+// TargetRun describes the execution of a make target for a module.
+type TargetRun struct {
+	ModulePath string
+	Makefile   string
+	Target     string
+	Version    string
+	// Env holds additional environment variables (KEY=value) for make
+	Env []string
+	// Output receives both stdout and stderr of make line by line, defaults to the process stdout
+	Output io.Writer
+	// LogPrefix is written in front of each line of output, i.e. [moduleID:target]
+	LogPrefix string
+	// LogPath is a file to which the output is also saved (without prefix) if set
+	LogPath string
+	// Timeout after which make is interrupted, no timeout if 0
+	Timeout time.Duration
+}
+
 // RunTarget executes the given target with VERSION=version injected into the env
 func RunTarget(modulePath, makefile, makeTarget, version string) error {
-	versionArg := fmt.Sprintf("VERSION=%s", version)
+	run := &TargetRun{
+		ModulePath: modulePath,
+		Makefile:   makefile,
+		Target:     makeTarget,
+		Version:    version,
+	}
+	return run.Run(context.Background())
+}
+
+// Run executes the make target with VERSION=version injected into the env,
+// make is interrupted if the context is cancelled or the timeout is reached.
+// When the target fails the last lines of its output are part of the returned error.
+func (run *TargetRun) Run(ctx context.Context) error {
+	ctx, cancel := process.WithTimeout(ctx, run.Timeout)
+	defer cancel()
+	versionArg := fmt.Sprintf("VERSION=%s", run.Version)
 	// We only execute make, the makefile and targets are in kaeter code, the version is the only outside argument
 	// and it comes from the versions.yaml file, since it is a key in a map it is also limited and validated by kaeter
 	// to a large extent.
-	cmd := exec.Command("make", "--file", makefile, "--environment-overrides", versionArg, makeTarget)
-	cmd.Dir = modulePath
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	err := cmd.Run() // TODO move to CombinedOutput() to avoid direct stdout output (see DryRunTarget())
+	cmd := process.Command(ctx, "make", "--file", run.Makefile, "--environment-overrides", versionArg, run.Target)
+	cmd.Dir = run.ModulePath
+	if len(run.Env) > 0 {
+		cmd.Env = append(os.Environ(), run.Env...)
+	}
+
+	lines := &outputWriter{prefix: run.LogPrefix, output: run.Output}
+	if lines.output == nil {
+		lines.output = os.Stdout
+	}
+	var output io.Writer = lines
+	if run.LogPath != "" {
+		logFile, err := createLogFile(run.LogPath)
+		if err != nil {
+			return err
+		}
+		defer logFile.Close()
+		output = io.MultiWriter(logFile, lines)
+	}
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	err := process.Describe(ctx, cmd.Run(), run.Timeout)
+	err = errors.Join(err, lines.flush())
 	if err != nil {
-		return fmt.Errorf("failed '%s' target on module %s: %w", makeTarget, modulePath, err)
+		return fmt.Errorf("failed '%s' target on module %s: %w%s", run.Target, run.ModulePath, err, lines.tailSummary())
 	}
 	return nil
 }
 
+func createLogFile(logPath string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		return nil, fmt.Errorf("unable to create log folder: %w", err)
+	}
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create log file: %w", err)
+	}
+	return logFile, nil
+}
+
 // DryRunTarget gets the output of a make dryrun on a given target
 func DryRunTarget(modulePath, makefile string, makeTargets []string) (string, error) {
 	// About the inputs of exec.Command
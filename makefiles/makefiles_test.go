@@ -1,7 +1,12 @@
 package makefiles
 
 import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -161,3 +166,89 @@ func TestDryRunTarget(t *testing.T) {
 		})
 	}
 }
+
+func TestTargetRunOutput(t *testing.T) {
+	testFolder := mocks.CreateTmpFolder(t)
+	mocks.CreateMockFile(t, testFolder, "Makefile", ".PHONY: build\nbuild:\n\t@echo building $(VERSION) of $(KAETER_MODULE_ID)")
+	output := &bytes.Buffer{}
+	run := &TargetRun{
+		ModulePath: testFolder,
+		Makefile:   "Makefile",
+		Target:     "build",
+		Version:    "1.2.3",
+		Env:        []string{"KAETER_MODULE_ID=ch.open:unit-test"},
+		Output:     output,
+	}
+
+	err := run.Run(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "building 1.2.3 of ch.open:unit-test\n", output.String())
+}
+
+func TestTargetRunLogs(t *testing.T) {
+	testFolder := mocks.CreateTmpFolder(t)
+	mocks.CreateMockFile(t, testFolder, "Makefile", ".PHONY: build\nbuild:\n\t@echo building\n\t@echo broken >&2; exit 1")
+	output := &bytes.Buffer{}
+	logPath := filepath.Join(testFolder, "logs", "build.log")
+	run := &TargetRun{
+		ModulePath: testFolder,
+		Makefile:   "Makefile",
+		Target:     "build",
+		Output:     output,
+		LogPrefix:  "[ch.open:unit-test:build] ",
+		LogPath:    logPath,
+	}
+
+	err := run.Run(t.Context())
+
+	assert.ErrorContains(t, err, "last lines of output:\nbuilding\nbroken\n")
+	assert.Contains(t, output.String(), "[ch.open:unit-test:build] building\n[ch.open:unit-test:build] broken\n")
+	logContent, readErr := os.ReadFile(logPath)
+	assert.NoError(t, readErr)
+	assert.Contains(t, string(logContent), "building\nbroken\n")
+}
+
+func TestTargetRunInterrupted(t *testing.T) {
+	testFolder := mocks.CreateTmpFolder(t)
+	mocks.CreateMockFile(t, testFolder, "Makefile", ".PHONY: build\nbuild:\n\tsleep 30")
+	var tests = []struct {
+		name          string
+		timeout       time.Duration
+		cancelContext bool
+		expectedError string
+	}{
+		{
+			name:          "Interrupted on timeout",
+			timeout:       100 * time.Millisecond,
+			expectedError: "timed out after 100ms",
+		},
+		{
+			name:          "Interrupted on context cancellation",
+			cancelContext: true,
+			expectedError: "context canceled",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+			if tc.cancelContext {
+				time.AfterFunc(100*time.Millisecond, cancel)
+			}
+			run := &TargetRun{
+				ModulePath: testFolder,
+				Makefile:   "Makefile",
+				Target:     "build",
+				Output:     &bytes.Buffer{},
+				Timeout:    tc.timeout,
+			}
+			start := time.Now()
+
+			err := run.Run(ctx)
+
+			assert.ErrorContains(t, err, tc.expectedError)
+			assert.Less(t, time.Since(start), 10*time.Second, "make should be interrupted rather than waited for")
+		})
+	}
+}
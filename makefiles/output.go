@@ -0,0 +1,62 @@
+package makefiles
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// tailLines is the number of output lines included in the error of a failed target
+const tailLines = 20
+
+// outputWriter splits the output of make in lines, writes each of them with a prefix
+// and keeps the last lines to report them if the target fails.
+type outputWriter struct {
+	prefix  string
+	output  io.Writer
+	partial []byte
+	tail    []string
+}
+
+func (w *outputWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		end := bytes.IndexByte(w.partial, '\n')
+		if end < 0 {
+			break
+		}
+		if err := w.writeLine(string(w.partial[:end])); err != nil {
+			return 0, err
+		}
+		w.partial = w.partial[end+1:]
+	}
+	return len(p), nil
+}
+
+// flush writes the last line if the output did not end with a new line.
+func (w *outputWriter) flush() error {
+	if len(w.partial) == 0 {
+		return nil
+	}
+	line := string(w.partial)
+	w.partial = nil
+	return w.writeLine(line)
+}
+
+func (w *outputWriter) writeLine(line string) error {
+	w.tail = append(w.tail, line)
+	if len(w.tail) > tailLines {
+		w.tail = w.tail[1:]
+	}
+	// Prefix and line are written at once so that lines of concurrent targets don't get mixed
+	_, err := io.WriteString(w.output, w.prefix+line+"\n")
+	return err
+}
+
+// tailSummary formats the last lines of output to be appended to an error.
+func (w *outputWriter) tailSummary() string {
+	if len(w.tail) == 0 {
+		return ""
+	}
+	return "\nlast lines of output:\n" + strings.Join(w.tail, "\n")
+}
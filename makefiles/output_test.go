@@ -0,0 +1,69 @@
+package makefiles
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputWriter(t *testing.T) {
+	var tests = []struct {
+		name           string
+		writes         []string
+		expectedOutput string
+		expectedTail   []string
+	}{
+		{
+			name:           "Prefixes each line",
+			writes:         []string{"first\nsecond\n"},
+			expectedOutput: "[id:build] first\n[id:build] second\n",
+			expectedTail:   []string{"first", "second"},
+		},
+		{
+			name:           "Joins lines split across writes",
+			writes:         []string{"fir", "st\nsec", "ond\n"},
+			expectedOutput: "[id:build] first\n[id:build] second\n",
+			expectedTail:   []string{"first", "second"},
+		},
+		{
+			name:           "Flushes last line without new line",
+			writes:         []string{"first\nlast"},
+			expectedOutput: "[id:build] first\n[id:build] last\n",
+			expectedTail:   []string{"first", "last"},
+		},
+		{
+			name:   "Empty output",
+			writes: []string{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			writer := &outputWriter{prefix: "[id:build] ", output: output}
+
+			for _, write := range tc.writes {
+				n, err := writer.Write([]byte(write))
+				assert.NoError(t, err)
+				assert.Equal(t, len(write), n)
+			}
+			assert.NoError(t, writer.flush())
+
+			assert.Equal(t, tc.expectedOutput, output.String())
+			assert.Equal(t, tc.expectedTail, writer.tail)
+		})
+	}
+}
+
+func TestOutputWriterTail(t *testing.T) {
+	writer := &outputWriter{output: &bytes.Buffer{}}
+	for i := range tailLines + 5 {
+		_, err := fmt.Fprintf(writer, "line %d\n", i)
+		assert.NoError(t, err)
+	}
+
+	assert.Len(t, writer.tail, tailLines)
+	assert.Equal(t, "line 5", writer.tail[0])
+	assert.Contains(t, writer.tailSummary(), fmt.Sprintf("line %d", tailLines+4))
+}
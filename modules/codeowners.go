@@ -0,0 +1,148 @@
+package modules
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// OwnerAnnotation allows overriding the owners resolved from CODEOWNERS for a module,
+// the value is a comma separated list of owners.
+const OwnerAnnotation = "open.ch/kaeter-owner"
+
+// codeOwnersLocations lists where CODEOWNERS files are looked up, in order of precedence
+// the first file found is used (same order as github, plus the gitlab specific location).
+var codeOwnersLocations = []string{ //nolint:gochecknoglobals
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+}
+
+// CodeOwners holds the parsed rules of a CODEOWNERS file.
+type CodeOwners struct {
+	rules []codeOwnersRule
+}
+
+type codeOwnersRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// ReadCodeOwners looks for a CODEOWNERS file in the usual locations of the repository
+// and parses it. If none can be found nil is returned without error.
+func ReadCodeOwners(repositoryRoot string) (*CodeOwners, error) {
+	for _, location := range codeOwnersLocations {
+		codeOwnersPath := filepath.Join(repositoryRoot, location)
+		if !fileExists(codeOwnersPath) {
+			continue
+		}
+		file, err := os.Open(codeOwnersPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %w", codeOwnersPath, err)
+		}
+		defer file.Close()
+		return parseCodeOwners(file)
+	}
+	return nil, nil //nolint:nilnil // not having a CODEOWNERS file is valid
+}
+
+func parseCodeOwners(file *os.File) (*CodeOwners, error) {
+	codeOwners := &CodeOwners{}
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		// Skip comments, empty lines and gitlab section headers ([Section] or ^[Optional section])
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+		if commentStart := strings.Index(line, " #"); commentStart > 0 {
+			line = line[:commentStart]
+		}
+		fields := strings.Fields(line)
+		pattern, err := codeOwnersPatternToRegexp(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid CODEOWNERS pattern on line %d: %w", lineNumber, err)
+		}
+		codeOwners.rules = append(codeOwners.rules, codeOwnersRule{
+			pattern: pattern,
+			owners:  fields[1:],
+		})
+	}
+	return codeOwners, scanner.Err()
+}
+
+// codeOwnersPatternToRegexp converts a gitignore style CODEOWNERS pattern to a regexp matching
+// paths relative to the repository root. The resulting regexp also matches anything below
+// a matching path since a rule for a folder applies to all its content.
+func codeOwnersPatternToRegexp(pattern string) (*regexp.Regexp, error) {
+	// A pattern with a slash at the start or in the middle is relative to the root,
+	// otherwise it matches at any depth.
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.Trim(pattern, "/")
+
+	var expression strings.Builder
+	if anchored {
+		expression.WriteString("^")
+	} else {
+		expression.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expression.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expression.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expression.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expression.WriteString("[^/]")
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		}
+	}
+	expression.WriteString("(?:/.*)?$")
+	return regexp.Compile(expression.String())
+}
+
+// OwnersOf returns the owners of the given path relative to the repository root,
+// following the CODEOWNERS semantic the last matching rule wins.
+func (co *CodeOwners) OwnersOf(relativePath string) []string {
+	if co == nil {
+		return nil
+	}
+	cleanPath := filepath.ToSlash(filepath.Clean(relativePath))
+	if cleanPath == "." {
+		cleanPath = ""
+	}
+	for i := len(co.rules) - 1; i >= 0; i-- {
+		if co.rules[i].pattern.MatchString(cleanPath) {
+			return co.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// resolveOwners sets the module owners from the owner annotation if present,
+// otherwise from the CODEOWNERS rules.
+func (mod *KaeterModule) resolveOwners(codeOwners *CodeOwners) {
+	if annotatedOwners, found := mod.Annotations[OwnerAnnotation]; found {
+		mod.Owners = nil
+		for owner := range strings.SplitSeq(annotatedOwners, ",") {
+			if trimmed := strings.TrimSpace(owner); trimmed != "" {
+				mod.Owners = append(mod.Owners, trimmed)
+			}
+		}
+		return
+	}
+	if owners := codeOwners.OwnersOf(mod.ModulePath); len(owners) > 0 {
+		mod.Owners = owners
+	}
+}
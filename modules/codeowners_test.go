@@ -0,0 +1,99 @@
+package modules
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-ch/kaeter/mocks"
+)
+
+const mockCodeOwners = `# Default owners
+*                       @org/default
+
+/services/              @org/services # inline comment
+/services/payments/     @org/payments @alice
+docs/                   @org/docs
+**/generated            @org/bots
+
+[Gitlab Section]
+/unowned/
+`
+
+func TestCodeOwnersOf(t *testing.T) {
+	var tests = []struct {
+		name     string
+		path     string
+		expected []string
+	}{
+		{name: "root falls back to default", path: ".", expected: []string{"@org/default"}},
+		{name: "unmatched path uses default", path: "tools/kaeter", expected: []string{"@org/default"}},
+		{name: "folder rule matches sub folders", path: "services/billing", expected: []string{"@org/services"}},
+		{name: "last matching rule wins", path: "services/payments", expected: []string{"@org/payments", "@alice"}},
+		{name: "nested module inherits", path: "services/payments/api", expected: []string{"@org/payments", "@alice"}},
+		{name: "unanchored folder matches at any depth", path: "libs/docs/module", expected: []string{"@org/docs"}},
+		{name: "double star pattern", path: "a/b/generated", expected: []string{"@org/bots"}},
+		{name: "rule without owners unsets owners", path: "unowned/module", expected: []string{}},
+	}
+
+	repoPath := mocks.CreateTmpFolder(t)
+	mocks.CreateMockFolder(t, repoPath, ".github")
+	mocks.CreateMockFile(t, filepath.Join(repoPath, ".github"), "CODEOWNERS", mockCodeOwners)
+	codeOwners, err := ReadCodeOwners(repoPath)
+	assert.NoError(t, err)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, codeOwners.OwnersOf(tc.path))
+		})
+	}
+}
+
+func TestReadCodeOwnersMissing(t *testing.T) {
+	repoPath := mocks.CreateTmpFolder(t)
+
+	codeOwners, err := ReadCodeOwners(repoPath)
+
+	assert.NoError(t, err)
+	assert.Nil(t, codeOwners)
+	assert.Nil(t, codeOwners.OwnersOf("any/path"))
+}
+
+func TestResolveOwners(t *testing.T) {
+	repoPath := mocks.CreateTmpFolder(t)
+	mocks.CreateMockFile(t, repoPath, "CODEOWNERS", "/services/ @org/services\n")
+	codeOwners, err := ReadCodeOwners(repoPath)
+	assert.NoError(t, err)
+
+	var tests = []struct {
+		name     string
+		module   KaeterModule
+		expected []string
+	}{
+		{
+			name:     "owners from CODEOWNERS",
+			module:   KaeterModule{ModulePath: "services/payments"},
+			expected: []string{"@org/services"},
+		},
+		{
+			name: "annotation overrides CODEOWNERS",
+			module: KaeterModule{
+				ModulePath:  "services/payments",
+				Annotations: map[string]string{OwnerAnnotation: "@org/payments, @bob"},
+			},
+			expected: []string{"@org/payments", "@bob"},
+		},
+		{
+			name:     "no owners",
+			module:   KaeterModule{ModulePath: "tools"},
+			expected: nil,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.module.resolveOwners(codeOwners)
+			assert.Equal(t, tc.expected, tc.module.Owners)
+		})
+	}
+}
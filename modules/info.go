@@ -25,6 +25,8 @@ const InitRef = "INIT"
 type NeedsReleaseInfo struct {
 	ModuleID   string `json:"moduleId"`
 	ModulePath string `json:"modulePath"`
+	// Owners of the module resolved from CODEOWNERS or the owner annotation
+	Owners []string `json:"owners,omitempty"`
 	// LatestReleaseTimestamp is based on the contents of the versions.yaml,
 	// and it will be nil if the module did not have a release.
 	LatestReleaseTimestamp *time.Time `json:"latestReleaseTimestamp"` // note: nill if no releases
@@ -237,6 +239,7 @@ func getModuleNeedsReleaseInfo(moduleInfo *KaeterModule) NeedsReleaseInfo {
 	return NeedsReleaseInfo{
 		ModuleID:                        moduleInfo.versions.ID,
 		ModulePath:                      moduleInfo.ModulePath,
+		Owners:                          moduleInfo.Owners,
 		LatestReleaseTimestamp:          latestReleaseTimestamp,
 		UnreleasedCommitCount:           commitCount,
 		UnreleasedDependencyCommitCount: dependenciesCommitCount,
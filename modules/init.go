@@ -90,6 +90,39 @@ func Initialize(config *InitializationConfig) (*Versions, error) {
 	return versions, nil
 }
 
+// InitializeMissingFiles creates the README.md and the CHANGELOG.md (as enabled in the config) of an existing
+// module from the templates if they are absent, its versions.yaml is left as is so ModuleID and VersioningScheme
+// must be set from it. Returns the absolute paths of the created files.
+func InitializeMissingFiles(config *InitializationConfig) ([]string, error) {
+	absPath, err := filepath.Abs(config.ModulePath)
+	if err != nil {
+		return nil, err
+	}
+	config.moduleAbsolutePath = absPath
+	config.ModuleDir = filepath.Base(absPath)
+	if config.Flavor == "" {
+		config.Flavor = defaultFlavor
+	}
+
+	created := []string{}
+	for _, file := range []struct {
+		path string
+		init func() error
+	}{
+		{filepath.Join(absPath, "README.md"), config.initReadmeIfNeeded},
+		{filepath.Join(absPath, "CHANGELOG.md"), config.initChangelogIfNeeded},
+	} {
+		existed := fileExists(file.path)
+		if err := file.init(); err != nil {
+			return created, err
+		}
+		if !existed && fileExists(file.path) {
+			created = append(created, file.path)
+		}
+	}
+	return created, nil
+}
+
 func validateVersioningScheme(versioningScheme string) (string, error) {
 	// Since we're taking the versioning scheme as an argument we compare it in a case insensitive way
 	// (with unicode case folding) to allow the flexibility of handling `"SEMVER"` or `"semver"
@@ -101,6 +101,30 @@ func TestInitialize(t *testing.T) {
 	}
 }
 
+func TestInitializeMissingFiles(t *testing.T) {
+	testFolder, _ := mocks.CreateMockRepo(t)
+	modulePath := path.Join(testFolder, "existingMod")
+	err := os.MkdirAll(modulePath, 0755)
+	assert.NoError(t, err)
+	err = os.WriteFile(path.Join(modulePath, "README.md"), []byte("# Existing README"), 0644)
+	assert.NoError(t, err)
+
+	created, err := InitializeMissingFiles(&InitializationConfig{
+		ModulePath:       modulePath,
+		ModuleID:         "ch.open.kaeter:existing",
+		VersioningScheme: SemVer,
+		InitChangelog:    true,
+		InitReadme:       true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{path.Join(modulePath, "CHANGELOG.md")}, created)
+	readme, err := os.ReadFile(path.Join(modulePath, "README.md"))
+	assert.NoError(t, err)
+	assert.Equal(t, "# Existing README", string(readme), "existing files are kept")
+	assert.NoFileExists(t, path.Join(modulePath, "versions.yaml"))
+}
+
 func TestValidateVersioningScheme(t *testing.T) {
 	var tests = []struct {
 		name       string
@@ -20,6 +20,7 @@ type KaeterModule struct {
 	AutoRelease  string            `json:"autoRelease,omitempty"`
 	Tags         []string          `json:"tags,omitempty"`
 	Dependencies []string          `json:"dependencies,omitempty"`
+	Owners       []string          `json:"owners,omitempty"`
 	// The following are useful at least as context within the modules package to avoid multiple loads of the same information
 	// if the turn out useful beyond the package scope we could make them public tho we have to be careful with impact
 	// on the JSON output and module detection, ideally it can be streamlined through the use of the inventory.
@@ -36,6 +37,11 @@ type FindResult struct {
 	Err     error
 }
 
+// ErrPath returns the path of the versions.yaml that failed to load, empty if unknown or no error
+func (result *FindResult) ErrPath() string {
+	return result.errPath
+}
+
 var (
 	// ErrModuleDependencyPath is generated when stats cannot be loaded for the dependency path
 	// in a kaeter module. Likely the path does not or no longer exists.
@@ -83,6 +89,8 @@ func GetKaeterModules(scanStartDir string) (modules []KaeterModule, err error) {
 // - ErrModuleDuplicateID per module for every module using an already encountered ID
 // - ErrModuleDependencyPath per module when dependencies contain invalid paths
 // - ErrModuleRelativePath per module if path isn't valid in repo path
+//
+// Module owners are resolved from the CODEOWNERS file of the repository if present.
 func StreamFoundIn(scanStartDir string) chan FindResult {
 	findingsChan := make(chan FindResult)
 	uniqueIDs := map[string]bool{}
@@ -96,6 +104,11 @@ func StreamFoundIn(scanStartDir string) chan FindResult {
 	go func() {
 		defer close(findingsChan)
 
+		codeOwners, err := ReadCodeOwners(repositoryRoot)
+		if err != nil {
+			log.Warn("Unable to resolve module owners from CODEOWNERS", "error", err)
+		}
+
 		versionsYamlFiles, err := findVersionsYamlFilesInPath(scanStartDir)
 		if err != nil {
 			findingsChan <- FindResult{Err: err}
@@ -114,6 +127,7 @@ func StreamFoundIn(scanStartDir string) chan FindResult {
 				}
 			} else {
 				uniqueIDs[module.ModuleID] = true
+				module.resolveOwners(codeOwners)
 				findingsChan <- FindResult{
 					Module: &module,
 				}
@@ -177,6 +191,15 @@ func (mod *KaeterModule) GetVersions() *Versions {
 	return mod.versions
 }
 
+// GetLatestRelease returns the latest actual release of the module ignoring pending autoreleases,
+// nil is returned if the versions are not loaded (i.e. module read from an inventory file).
+func (mod *KaeterModule) GetLatestRelease() *VersionMetadata {
+	if mod.versions == nil || len(mod.versions.ReleasedVersions) == 0 {
+		return nil
+	}
+	return getLatestRelease(mod.versions.ReleasedVersions)
+}
+
 // GetVersionsPath returns the absolute path to the versions.yaml file
 func (mod *KaeterModule) GetVersionsPath() string {
 	return mod.versionsFileAbsPath
@@ -0,0 +1,81 @@
+package modules
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/spf13/viper"
+)
+
+// releasePipelineViperKey is the key of the repository wide default pipeline in the kaeter config
+const releasePipelineViperKey = "release"
+
+// stepNamePattern matches the make targets which can be used as release steps
+var stepNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`) //nolint:gochecknoglobals
+
+// ReleasePipeline defines the make targets (steps) run in order to release a module
+// and the last of them run for dry runs.
+type ReleasePipeline struct {
+	Steps       []string `yaml:"steps,omitempty" mapstructure:"steps"`
+	DryRunUntil string   `yaml:"dryRunUntil,omitempty" mapstructure:"dryRunUntil"`
+}
+
+// DefaultReleasePipeline returns the pipeline used unless configured otherwise:
+// build, test and release, stopping after test for dry runs.
+func DefaultReleasePipeline() *ReleasePipeline {
+	return &ReleasePipeline{
+		Steps:       []string{"build", "test", "release"},
+		DryRunUntil: "test",
+	}
+}
+
+// GetReleasePipeline returns the release pipeline of the module from versions.yaml, or else
+// the repository default from the kaeter config, or else the DefaultReleasePipeline.
+func (v *Versions) GetReleasePipeline() (*ReleasePipeline, error) {
+	pipeline := v.Release
+	if pipeline == nil && viper.IsSet(releasePipelineViperKey) {
+		pipeline = &ReleasePipeline{}
+		if err := viper.UnmarshalKey(releasePipelineViperKey, pipeline); err != nil {
+			return nil, fmt.Errorf("invalid release pipeline in kaeter config: %w", err)
+		}
+	}
+	if pipeline == nil {
+		return DefaultReleasePipeline(), nil
+	}
+	if err := pipeline.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid release pipeline for %s: %w", v.ID, err)
+	}
+	return pipeline, nil
+}
+
+// Validate checks that the pipeline has at least one step, each only once, and that
+// the dry run cut-off is one of the steps.
+func (p *ReleasePipeline) Validate() error {
+	if len(p.Steps) == 0 {
+		return errors.New("release pipeline has no steps")
+	}
+	var errs error
+	for i, step := range p.Steps {
+		if !stepNamePattern.MatchString(step) {
+			errs = errors.Join(errs, fmt.Errorf("invalid step name '%s'", step))
+		}
+		if slices.Contains(p.Steps[:i], step) {
+			errs = errors.Join(errs, fmt.Errorf("step '%s' is listed more than once", step))
+		}
+	}
+	if p.DryRunUntil != "" && !slices.Contains(p.Steps, p.DryRunUntil) {
+		errs = errors.Join(errs, fmt.Errorf("dryRunUntil '%s' is not one of the steps %v", p.DryRunUntil, p.Steps))
+	}
+	return errs
+}
+
+// DryRunSteps returns the steps run for dry runs: up to and including DryRunUntil,
+// or all steps but the last if it is not set.
+func (p *ReleasePipeline) DryRunSteps() []string {
+	if p.DryRunUntil == "" {
+		return p.Steps[:len(p.Steps)-1]
+	}
+	return p.Steps[:slices.Index(p.Steps, p.DryRunUntil)+1]
+}
@@ -0,0 +1,151 @@
+package modules
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalReleasePipeline(t *testing.T) {
+	versionsContent := fmt.Sprintf(templateMetadataVersion, `release:
+    steps: [lint, build, publish]
+    dryRunUntil: build`)
+
+	versions, err := unmarshalVersions([]byte(versionsContent))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &ReleasePipeline{Steps: []string{"lint", "build", "publish"}, DryRunUntil: "build"}, versions.Release)
+	marshalled, err := versions.Marshal()
+	assert.NoError(t, err)
+	assert.Contains(t, string(marshalled), "dryRunUntil: build")
+}
+
+func TestGetReleasePipeline(t *testing.T) {
+	var tests = []struct {
+		name             string
+		release          *ReleasePipeline
+		config           map[string]any
+		expectedPipeline *ReleasePipeline
+		expectedError    bool
+	}{
+		{
+			name:             "Defaults to build, test and release",
+			expectedPipeline: DefaultReleasePipeline(),
+		},
+		{
+			name:             "Uses the repository default from the config",
+			config:           map[string]any{"steps": []string{"build", "publish"}},
+			expectedPipeline: &ReleasePipeline{Steps: []string{"build", "publish"}},
+		},
+		{
+			name:             "Module pipeline overrides the config",
+			release:          &ReleasePipeline{Steps: []string{"package", "upload"}, DryRunUntil: "package"},
+			config:           map[string]any{"steps": []string{"build", "publish"}},
+			expectedPipeline: &ReleasePipeline{Steps: []string{"package", "upload"}, DryRunUntil: "package"},
+		},
+		{
+			name:          "Fails for invalid config pipeline",
+			config:        map[string]any{"steps": []string{}},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			if tc.config != nil {
+				viper.Set(releasePipelineViperKey, tc.config)
+			}
+			versions := &Versions{ID: "ch.open.kaeter:unit-test", Release: tc.release}
+
+			pipeline, err := versions.GetReleasePipeline()
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedPipeline, pipeline)
+		})
+	}
+	viper.Reset()
+}
+
+func TestReleasePipelineValidate(t *testing.T) {
+	var tests = []struct {
+		name     string
+		pipeline ReleasePipeline
+		valid    bool
+	}{
+		{
+			name:     "Default pipeline is valid",
+			pipeline: *DefaultReleasePipeline(),
+			valid:    true,
+		},
+		{
+			name:     "Single step is valid",
+			pipeline: ReleasePipeline{Steps: []string{"publish"}},
+			valid:    true,
+		},
+		{
+			name:     "Fails without steps",
+			pipeline: ReleasePipeline{},
+		},
+		{
+			name:     "Fails for invalid step name",
+			pipeline: ReleasePipeline{Steps: []string{"build", "-i release"}},
+		},
+		{
+			name:     "Fails for duplicate step",
+			pipeline: ReleasePipeline{Steps: []string{"build", "test", "build"}},
+		},
+		{
+			name:     "Fails if dry run cut-off is not a step",
+			pipeline: ReleasePipeline{Steps: []string{"build", "release"}, DryRunUntil: "test"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.pipeline.Validate()
+
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestReleasePipelineDryRunSteps(t *testing.T) {
+	var tests = []struct {
+		name          string
+		pipeline      ReleasePipeline
+		expectedSteps []string
+	}{
+		{
+			name:          "Stops at the cut-off step",
+			pipeline:      ReleasePipeline{Steps: []string{"lint", "build", "test", "publish"}, DryRunUntil: "build"},
+			expectedSteps: []string{"lint", "build"},
+		},
+		{
+			name:          "Skips only the last step by default",
+			pipeline:      ReleasePipeline{Steps: []string{"lint", "build", "publish"}},
+			expectedSteps: []string{"lint", "build"},
+		},
+		{
+			name:          "Cut-off on the last step runs everything",
+			pipeline:      ReleasePipeline{Steps: []string{"build", "publish"}, DryRunUntil: "publish"},
+			expectedSteps: []string{"build", "publish"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSteps, tc.pipeline.DryRunSteps())
+		})
+	}
+}
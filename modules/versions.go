@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -25,13 +26,14 @@ type VersionSlice []*VersionMetadata
 
 // Versions is a fully unmarshalled representation of a versions.yaml file
 type Versions struct {
-	ID               string          `yaml:"id"`
-	ModuleType       string          `yaml:"type"`
-	Metadata         *Metadata       `yaml:"metadata,omitempty"`
-	Dependencies     []string        `yaml:"dependencies,omitempty"`
-	VersioningType   string          `yaml:"versioning"`
-	ReleasedVersions VersionSlice    `yaml:"versions"`
-	commentMap       yaml.CommentMap // Store comments for preservation
+	ID               string           `yaml:"id"`
+	ModuleType       string           `yaml:"type"`
+	Metadata         *Metadata        `yaml:"metadata,omitempty"`
+	Dependencies     []string         `yaml:"dependencies,omitempty"`
+	Release          *ReleasePipeline `yaml:"release,omitempty"`
+	VersioningType   string           `yaml:"versioning"`
+	ReleasedVersions VersionSlice     `yaml:"versions"`
+	commentMap       yaml.CommentMap  // Store comments for preservation
 }
 
 // Metadata holds the parsed Annotations from versions.yaml if present
@@ -57,12 +59,13 @@ func (v *Versions) UnmarshalYAML(unmarshal func(any) error) error {
 	// Define a temporary structure for initial unmarshaling
 	// it allows to parse top-level fields and the versions as a yaml.MapSlice
 	type tempVersions struct {
-		ID             string        `yaml:"id"`
-		ModuleType     string        `yaml:"type"`
-		VersioningType string        `yaml:"versioning"`
-		Versions       yaml.MapSlice `yaml:"versions"`
-		Metadata       *Metadata     `yaml:"metadata,omitempty"`
-		Dependencies   []string      `yaml:"dependencies,omitempty"`
+		ID             string           `yaml:"id"`
+		ModuleType     string           `yaml:"type"`
+		VersioningType string           `yaml:"versioning"`
+		Versions       yaml.MapSlice    `yaml:"versions"`
+		Metadata       *Metadata        `yaml:"metadata,omitempty"`
+		Dependencies   []string         `yaml:"dependencies,omitempty"`
+		Release        *ReleasePipeline `yaml:"release,omitempty"`
 	}
 
 	var temp tempVersions
@@ -76,6 +79,7 @@ func (v *Versions) UnmarshalYAML(unmarshal func(any) error) error {
 	v.VersioningType = temp.VersioningType
 	v.Metadata = temp.Metadata
 	v.Dependencies = temp.Dependencies
+	v.Release = temp.Release
 
 	// Process versions from yaml.MapSlice
 	v.ReleasedVersions = make(VersionSlice, 0, len(temp.Versions))
@@ -207,6 +211,25 @@ func (v *Versions) AddRelease(refTime *time.Time, bumpType SemVerBump, userProvi
 	return nextMetadata, nil
 }
 
+// FirstUnsortedRelease returns the index of the first released version listed before a version released
+// earlier, -1 if the versions are in release order.
+func (v *Versions) FirstUnsortedRelease() int {
+	for i := 1; i < len(v.ReleasedVersions); i++ {
+		if v.ReleasedVersions[i].Timestamp.Before(v.ReleasedVersions[i-1].Timestamp) {
+			return i
+		}
+	}
+	return -1
+}
+
+// SortReleases sorts the released versions by release date, versions released at the same time keep
+// their order. Note that this does not yet update the YAML file.
+func (v *Versions) SortReleases() {
+	slices.SortStableFunc(v.ReleasedVersions, func(a, b *VersionMetadata) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+}
+
 // Marshal serializes this instance to YAML and returns the corresponding bytes
 func (v *Versions) Marshal() ([]byte, error) {
 	return yaml.MarshalWithOptions(v, yaml.WithComment(v.commentMap), yaml.IndentSequence(true))
@@ -798,3 +798,60 @@ versions:
 	assert.NoError(t, err, "should marshal without error")
 	assert.Equal(t, expectedMashalledContent, string(marshaledYAML))
 }
+
+func TestSortReleases(t *testing.T) {
+	var tests = []struct {
+		name             string
+		versions         string
+		expectedUnsorted int
+		expectedOrder    []string
+	}{
+		{
+			name:             "sorted versions, released at the same time or not",
+			versions:         sampleSemVerVersion,
+			expectedUnsorted: -1,
+			expectedOrder:    []string{"0.0.0", "1.1.1", "1.2.0", "1.2.0-1", "v2.0.0", "v2.0.0-1+2"},
+		},
+		{
+			name: "versions sorted by release date",
+			versions: `id: testGroup:testModule
+type: Makefile
+versioning: SemVer
+versions:
+  0.0.0: 2019-04-01T16:06:07Z|INIT
+  1.2.0: 2019-04-03T16:06:07Z|aa4b40f6862a2dc28f4045bd57d1832dfde10e55
+  1.1.0: 2019-04-02T16:06:07Z|934b40f6862a2dc28f4045bd57d1832dfde10e55
+  1.1.1: 2019-04-02T16:06:07Z|934b40f6862a2dc28f4045bd57d1832dfde10e66
+`,
+			expectedUnsorted: 2,
+			expectedOrder:    []string{"0.0.0", "1.1.0", "1.1.1", "1.2.0"},
+		},
+		{
+			name: "AnyStringVer versions sorted by release date",
+			versions: `id: testGroup:testModule
+type: Makefile
+versioning: AnyStringVer
+versions:
+  b: 2019-04-02T16:06:07Z|aa4b40f6862a2dc28f4045bd57d1832dfde10e55
+  a: 2019-04-01T16:06:07Z|934b40f6862a2dc28f4045bd57d1832dfde10e55
+`,
+			expectedUnsorted: 1,
+			expectedOrder:    []string{"a", "b"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			versions := parseVersions(t, tc.versions)
+
+			assert.Equal(t, tc.expectedUnsorted, versions.FirstUnsortedRelease())
+			versions.SortReleases()
+			var order []string
+			for _, release := range versions.ReleasedVersions {
+				order = append(order, release.Number.String())
+			}
+			assert.Equal(t, tc.expectedOrder, order)
+			assert.Equal(t, -1, versions.FirstUnsortedRelease())
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Package process helps running external commands (make targets, hooks) which can be
+// interrupted: each command runs in its own process group and when its context is
+// cancelled the signal which interrupted kaeter, or SIGTERM on timeout, is sent to the
+// whole group before it gets killed after a grace period.
+package process
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// GracePeriod is how long a command has to exit once signaled before it is killed.
+const GracePeriod = 10 * time.Second
+
+// InterruptedError is the cause of contexts cancelled by a signal, see NotifyContext.
+type InterruptedError struct {
+	Signal os.Signal
+}
+
+func (e *InterruptedError) Error() string {
+	return fmt.Sprintf("interrupted by %s", e.Signal)
+}
+
+// NotifyContext returns a context cancelled with an InterruptedError cause on the first
+// SIGINT or SIGTERM. Later signals are not caught anymore and terminate kaeter as usual.
+func NotifyContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case sig := <-signals:
+			signal.Stop(signals)
+			cancel(&InterruptedError{Signal: sig})
+		case <-ctx.Done():
+			signal.Stop(signals)
+		}
+	}()
+	return ctx, func() { cancel(context.Canceled) }
+}
+
+// WithTimeout returns a context with the given timeout, or without timeout if it is 0.
+func WithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// Command creates a command which is interrupted when the context is cancelled, see package doc.
+func Command(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	setProcessGroup(cmd)
+	cmd.Cancel = func() error {
+		return signalProcessGroup(cmd, cancelSignal(ctx))
+	}
+	cmd.WaitDelay = GracePeriod
+	return cmd
+}
+
+// Describe wraps the error of a command with the reason it was interrupted,
+// if it was interrupted, otherwise err is returned as is.
+func Describe(ctx context.Context, err error, timeout time.Duration) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s: %w", timeout, err)
+	}
+	return fmt.Errorf("%w: %w", context.Cause(ctx), err)
+}
+
+func cancelSignal(ctx context.Context) os.Signal {
+	if interrupted, ok := errors.AsType[*InterruptedError](context.Cause(ctx)); ok {
+		return interrupted.Signal
+	}
+	return syscall.SIGTERM
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package process
+
+import (
+	"os"
+	"os/exec"
+)
+
+func setProcessGroup(_ *exec.Cmd) {}
+
+// signalProcessGroup kills the process since signals cannot be forwarded on this platform.
+func signalProcessGroup(cmd *exec.Cmd, _ os.Signal) error {
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,54 @@
+package process
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribe(t *testing.T) {
+	commandErr := errors.New("signal: terminated")
+	timedOut, cancelTimeout := context.WithTimeout(t.Context(), 0)
+	defer cancelTimeout()
+	interrupted, interrupt := context.WithCancelCause(t.Context())
+	interrupt(&InterruptedError{Signal: os.Interrupt})
+
+	var tests = []struct {
+		name          string
+		ctx           context.Context
+		err           error
+		expectedError string
+	}{
+		{name: "No error", ctx: t.Context()},
+		{name: "Error of running context", ctx: t.Context(), err: commandErr, expectedError: "signal: terminated"},
+		{name: "Timed out", ctx: timedOut, err: commandErr, expectedError: "timed out after 1m0s: signal: terminated"},
+		{name: "Interrupted", ctx: interrupted, err: commandErr, expectedError: "interrupted by interrupt: signal: terminated"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Describe(tc.ctx, tc.err, time.Minute)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.expectedError)
+			assert.ErrorIs(t, err, commandErr)
+		})
+	}
+}
+
+func TestCancelSignal(t *testing.T) {
+	interrupted, interrupt := context.WithCancelCause(t.Context())
+	interrupt(&InterruptedError{Signal: os.Interrupt})
+	cancelled, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	assert.Equal(t, os.Interrupt, cancelSignal(interrupted))
+	assert.Equal(t, syscall.SIGTERM, cancelSignal(cancelled))
+}
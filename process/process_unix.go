@@ -0,0 +1,22 @@
+//go:build unix
+
+package process
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+func signalProcessGroup(cmd *exec.Cmd, sig os.Signal) error {
+	unixSignal, ok := sig.(syscall.Signal)
+	if !ok {
+		unixSignal = syscall.SIGTERM
+	}
+	// A negative pid signals the whole process group, the group id is the pid of the leader
+	return syscall.Kill(-cmd.Process.Pid, unixSignal)
+}